@@ -1,9 +1,15 @@
 package trustpilot
 
 import (
-	"encoding/json"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"fmt"
-	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 )
 
 var (
@@ -26,12 +32,40 @@ type Authorization struct {
 	AccessToken  *string `json:"access_token,omitmpty"`
 	RefreshToken *string `json:"refresh_token,omitempty"`
 	ExpiresIN    *string `json:"expires_in,omitempty"`
+
+	// Expiry is the time the access token expires, computed from ExpiresIN
+	// when the token was issued. It is nil if the expiry is unknown. Unlike
+	// ExpiresIN it stays valid when the Authorization is saved and reloaded.
+	Expiry *Timestamp `json:"expiry,omitempty"`
 }
 
 func (a Authorization) String() string {
 	return Stringify(a)
 }
 
+// ExpiresInDuration returns ExpiresIN, which Trustpilot sends as a number of
+// seconds, as a time.Duration. It returns 0 if ExpiresIN is unset or invalid.
+func (a *Authorization) ExpiresInDuration() time.Duration {
+	secs, err := strconv.ParseInt(StringValue(a.ExpiresIN), 10, 64)
+	if err != nil || secs <= 0 {
+		return 0
+	}
+	return time.Duration(secs) * time.Second
+}
+
+// Expired reports whether the access token has expired at time now. A token
+// with an unknown expiry is never considered expired.
+func (a *Authorization) Expired(now time.Time) bool {
+	return a.Expiry != nil && !now.Before(a.Expiry.Time)
+}
+
+// setExpiry sets Expiry relative to issuedAt.
+func (a *Authorization) setExpiry(issuedAt time.Time) {
+	if d := a.ExpiresInDuration(); d > 0 {
+		a.Expiry = &Timestamp{issuedAt.Add(d)}
+	}
+}
+
 // AuthorizationApp represents an individual trustpilot app (in the context of authorization).
 type AuthorizationApp struct {
 	AccessToken  *string `json:"access_token,omitmpty"`
@@ -43,61 +77,172 @@ func (a AuthorizationApp) String() string {
 	return Stringify(a)
 }
 
-//AuthorizationCode The user is redirected to a website owned by Trustpilot in order to be authorized. After the authorization succeeds,
-//Trustpilot redirects the user back to the client site with a code parameter containing the authorization code.
+// ErrInvalidState is passed to CallbackHandler.OnAuthorization when the
+// state parameter of a redirect was not issued by the application.
+var ErrInvalidState = errors.New("trustpilot: invalid OAuth state parameter")
+
+// AuthCodeURL returns the URL of the Trustpilot page the business user is
+// redirected to in order to authorize the application. After the
+// authorization succeeds, Trustpilot redirects the user back to redirectURL
+// with a code parameter containing the authorization code, along with state.
 //
-//https://developers.trustpilot.com/authentication
-func (a *AuthorizationsService) AuthorizationCode(redirectURL string) (string, error) {
-	u := fmt.Sprintf("%s/authenticate?response_type=code&client_id=%v&redirect_uri=%v", fakeURL, a.client.ClientID, redirectURL)
-	if isTEST {
-		u = fmt.Sprintf("%s?response_type=code&client_id=%v&redirect_uri=%v", "/v1/oauth/oauth-business-users-for-applications/authenticate", a.client.ClientID, redirectURL)
-	}
+// state protects against CSRF and should be a fresh value from NewState that
+// is remembered, e.g. in the user's session, until the redirect comes back.
+//
+// https://developers.trustpilot.com/authentication#authorization-code
+func (a *AuthorizationsService) AuthCodeURL(redirectURL, state string) string {
 	//https://authenticate.trustpilot.com?client_id=APIKey&redirect_uri=https://www.clientsSite.com&response_type=code
-	req, err := a.client.NewRequest("GET", u, nil)
-	if err != nil {
-		log.Printf("Err %v", err)
-		return "", err
+	u := *a.client.AuthURL
+	q := u.Query()
+	q.Set("response_type", "code")
+	q.Set("client_id", a.client.ClientID)
+	q.Set("redirect_uri", redirectURL)
+	if state != "" {
+		q.Set("state", state)
 	}
-	resp, err := a.client.Do(a.client.CTX, req)
-	if err != nil {
-		log.Printf("Err1 %v", err)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// NewState returns a random value suitable as the state parameter of
+// AuthCodeURL.
+func NewState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
-	//Redirects back to: https://www.clientsSite.com/?code=Code as the response
-	return string(resp), nil
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// CallbackHandler is an http.Handler serving the redirect URL of the
+// authorization code flow. It validates the state parameter, exchanges the
+// code for an access token with RetrieveAccessToken and hands the result to
+// OnAuthorization.
+type CallbackHandler struct {
+	Authorizations *AuthorizationsService
+
+	// RedirectURL must be the redirect URL given to AuthCodeURL.
+	RedirectURL string
+
+	// ValidState reports whether state was issued for the user making
+	// request r. A nil ValidState rejects every redirect.
+	ValidState func(r *http.Request, state string) bool
+
+	// OnAuthorization is called with the Authorization, or with the error
+	// that prevented getting one, and is responsible for writing the
+	// response.
+	OnAuthorization func(w http.ResponseWriter, r *http.Request, auth *Authorization, err error)
+}
+
+func (h *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if h.ValidState == nil || !h.ValidState(r, q.Get("state")) {
+		h.OnAuthorization(w, r, nil, ErrInvalidState)
+		return
+	}
+	if e := q.Get("error"); e != "" {
+		h.OnAuthorization(w, r, nil, fmt.Errorf("trustpilot: authorization failed: %s", e))
+		return
+	}
+	code := q.Get("code")
+	if code == "" {
+		h.OnAuthorization(w, r, nil, errors.New("trustpilot: redirect has no code parameter"))
+		return
+	}
+	auth, _, err := h.Authorizations.RetrieveAccessToken(r.Context(), code, h.RedirectURL)
+	h.OnAuthorization(w, r, auth, err)
 }
 
 //RetrieveAccessToken Convert an authorization code to an access token
-func (a *AuthorizationsService) RetrieveAccessToken(code string, redirectURL string) (*Authorization, error) {
+func (a *AuthorizationsService) RetrieveAccessToken(ctx context.Context, code string, redirectURL string) (*Authorization, *Response, error) {
 	//https://api.trustpilot.com/v1/oauth/oauth-business-users-for-applications/accesstoken
 	// send the request
 	//grant_type=authorization_code&code=Code&redirect_uri=https://www.clientsSite.com
-	reqBody := &struct {
-		GrantType   string `json:"grant_type"`
-		Code        string `json:"code"`
-		RedirectURI string `json:"redirect_uri"`
-	}{GrantType: "authorization_code", Code: code, RedirectURI: redirectURL}
-	if isTEST {
-		accessTokenURL = fmt.Sprintf("%s", "/v1/oauth/oauth-business-users-for-applications/accesstoken")
-	} else {
-		accessTokenURL = fakeURL + "accesstoken"
-	}
-	req, err := a.client.NewRequest("POST", accessTokenURL, reqBody)
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURL)
+	return a.requestToken(ctx, a.client.AccessTokenURL, form)
+}
+
+// PasswordGrant gets an access token using the resource owner password
+// credentials grant, authenticating as the business user with the given
+// username and password. It needs no browser redirect, which makes it suited
+// for backend jobs.
+//
+// https://developers.trustpilot.com/authentication#password
+func (a *AuthorizationsService) PasswordGrant(ctx context.Context, username, password string) (*Authorization, *Response, error) {
+	//grant_type=password&username=USERNAME&password=PASSWORD
+	form := url.Values{}
+	form.Set("grant_type", "password")
+	form.Set("username", username)
+	form.Set("password", password)
+	return a.requestToken(ctx, a.client.AccessTokenURL, form)
+}
+
+// ClientCredentials gets an access token for the application itself using
+// the client credentials grant. Only the application's ClientID and
+// ClientSecret are needed.
+//
+// https://developers.trustpilot.com/authentication#client-credentials
+func (a *AuthorizationsService) ClientCredentials(ctx context.Context) (*Authorization, *Response, error) {
+	//grant_type=client_credentials
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	return a.requestToken(ctx, a.client.AccessTokenURL, form)
+}
+
+// RefreshAccessToken exchanges a refresh token for a new access token.
+// Access tokens expire after the duration given in ExpiresIN; the refresh
+// token can be used to get a new one without asking the business user to
+// authorize the application again.
+//
+// https://developers.trustpilot.com/authentication#refresh-access-token
+func (a *AuthorizationsService) RefreshAccessToken(ctx context.Context, refreshToken string) (*Authorization, *Response, error) {
+	//grant_type=refresh_token&refresh_token=RefreshToken
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", refreshToken)
+	return a.requestToken(ctx, a.client.RefreshURL, form)
+}
+
+// RevokeToken revokes an access or refresh token, e.g. when a business user
+// uninstalls the application. A failed revocation is returned as an
+// *ErrorResponse.
+//
+// https://developers.trustpilot.com/authentication#revoke-refresh-token
+func (a *AuthorizationsService) RevokeToken(ctx context.Context, token string) (*Response, error) {
+	//token=RefreshToken
+	form := url.Values{}
+	form.Set("token", token)
+	req, err := a.client.NewFormRequest("POST", a.client.RevokeURL.String(), form)
 	if err != nil {
 		return nil, err
 	}
-	auth := new(Authorization)
-	bc := base64Encode(fmt.Sprintf("%s:%s", a.client.ClientID, a.client.ClientSecret))
-	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
-	req.Header.Add("Authorization", "Basic "+bc)
-	resp, err := a.client.Do(a.client.CTX, req)
+	a.setBasicAuth(req)
+	return a.client.Do(ctx, req, nil)
+}
+
+// requestToken posts form to the OAuth endpoint u using the application
+// credentials and decodes the issued Authorization.
+func (a *AuthorizationsService) requestToken(ctx context.Context, u *url.URL, form url.Values) (*Authorization, *Response, error) {
+	req, err := a.client.NewFormRequest("POST", u.String(), form)
 	if err != nil {
-		return auth, err
+		return nil, nil, err
 	}
-	err = json.Unmarshal(resp, &auth) // convert the response data to json
+	a.setBasicAuth(req)
 
+	auth := new(Authorization)
+	resp, err := a.client.Do(ctx, req, auth)
 	if err != nil {
-		return nil, err
+		return nil, resp, err
 	}
-	return auth, nil
+	auth.setExpiry(time.Now())
+	return auth, resp, nil
+}
+
+// setBasicAuth authenticates req with the application's client credentials.
+func (a *AuthorizationsService) setBasicAuth(req *http.Request) {
+	bc := base64Encode(fmt.Sprintf("%s:%s", a.client.ClientID, a.client.ClientSecret))
+	req.Header.Set("Authorization", "Basic "+bc)
 }
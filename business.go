@@ -1,9 +1,12 @@
 package trustpilot
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 // BusinessService handles communication with the business related
@@ -12,11 +15,40 @@ import (
 // GitHub API docs: https://developers.trustpilot.com/business-units-api#get-a-list-of-all-business-units
 type BusinessService service
 
-// Business represents an individual business
+// Business represents an individual business unit and its public profile
 type Business struct {
-	DisplayName *string `json:"displayName,omitmpty"`
-	ID          *string `json:"id,omitempty"`
-	Links       []Links `json:"links,omitempty"`
+	DisplayName     *string          `json:"displayName,omitmpty"`
+	ID              *string          `json:"id,omitempty"`
+	Links           []Links          `json:"links,omitempty"`
+	Name            *BusinessName    `json:"name,omitempty"`
+	WebsiteURL      *string          `json:"websiteUrl,omitempty"`
+	Country         *string          `json:"country,omitempty"`
+	Status          *string          `json:"status,omitempty"`
+	Score           *BusinessScore   `json:"score,omitempty"`
+	NumberOfReviews *NumberOfReviews `json:"numberOfReviews,omitempty"`
+}
+
+// BusinessName holds the domain names a business unit is known by
+type BusinessName struct {
+	Identifying *string  `json:"identifying,omitempty"`
+	Referring   []string `json:"referring,omitempty"`
+}
+
+// BusinessScore is the TrustScore of a business unit, and its rounding to stars
+type BusinessScore struct {
+	TrustScore *float64 `json:"trustScore,omitempty"`
+	Stars      *float64 `json:"stars,omitempty"`
+}
+
+// NumberOfReviews counts the reviews of a business unit by star rating
+type NumberOfReviews struct {
+	Total                        *int `json:"total,omitempty"`
+	UsedForTrustScoreCalculation *int `json:"usedForTrustScoreCalculation,omitempty"`
+	OneStar                      *int `json:"oneStar,omitempty"`
+	TwoStars                     *int `json:"twoStars,omitempty"`
+	ThreeStars                   *int `json:"threeStars,omitempty"`
+	FourStars                    *int `json:"fourStars,omitempty"`
+	FiveStars                    *int `json:"fiveStars,omitempty"`
 }
 
 //Links represents the business links
@@ -31,37 +63,85 @@ func (b BusinessService) String() string {
 }
 
 //GetBusinessCredentials Returns the business unit given by the provided name
-func (b *BusinessService) GetBusinessCredentials(token, search string) (*Business, error) {
-	u := fmt.Sprintf("%s/v1/business-units/find?name=%s", fakeURL, search)
-	if isTEST {
-		u = fmt.Sprintf("/v1/business-units/find?name=%s", search)
+//This is a public method authenticated with the application's API key (ClientID), see GetBusinessUnitByDomain
+//to look a business unit up by its website.
+//
+//https://developers.trustpilot.com/business-units-api#find-a-business-unit
+func (b *BusinessService) GetBusinessCredentials(ctx context.Context, search string) (*Business, *Response, error) {
+	u := fmt.Sprintf("business-units/find?name=%s", url.QueryEscape(search))
+	bs := new(Business)
+	req, err := b.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
 	}
+
+	req.Header.Add("Authorization", ""+b.client.ClientID)
+	resp, err := b.client.Do(ctx, req, bs)
+	if err != nil {
+		return nil, resp, err
+	}
+	return bs, resp, nil
+}
+
+//GetBusinessUnit Get a business unit
+//This method gets the public profile of the business unit with the given ID, including its TrustScore,
+//star rating and number of reviews by star.
+//
+//https://developers.trustpilot.com/business-units-api#get-public-business-unit
+func (b *BusinessService) GetBusinessUnit(ctx context.Context, businessUnitID string) (*Business, *Response, error) {
+	u := fmt.Sprintf("business-units/%s", businessUnitID)
 	bs := new(Business)
 	req, err := b.client.NewRequest("GET", u, nil)
 	if err != nil {
-		log.Printf("Err %v", err)
-		return bs, err
+		return nil, nil, err
 	}
 
-	req.Header.Add("Authorization", ""+token)
-	resp, err := b.client.Do(b.client.CTX, req)
+	req.Header.Add("Authorization", ""+b.client.ClientID)
+	resp, err := b.client.Do(ctx, req, bs)
+	if err != nil {
+		return nil, resp, err
+	}
+	return bs, resp, nil
+}
+
+//GetBusinessUnitByDomain Find a business unit by its domain
+//domain may be a bare domain such as "trustpilot.com" or a website URL such as "https://www.trustpilot.com/about",
+//in which case its host is looked up with GetBusinessCredentials.
+//
+//https://developers.trustpilot.com/business-units-api#find-a-business-unit
+func (b *BusinessService) GetBusinessUnitByDomain(ctx context.Context, domain string) (*Business, *Response, error) {
+	host, err := domainOf(domain)
 	if err != nil {
-		log.Printf("Err1 %v", err)
-		return bs, err
+		return nil, nil, err
 	}
-	err = json.Unmarshal(resp, &bs) // convert the response data to json
+	return b.GetBusinessCredentials(ctx, host)
+}
 
+// domainOf returns the lower-cased host of a domain or website URL.
+func domainOf(website string) (string, error) {
+	s := strings.TrimSpace(website)
+	if !strings.Contains(s, "://") {
+		s = "http://" + s
+	}
+	u, err := url.Parse(s)
 	if err != nil {
-		return bs, err
+		return "", err
+	}
+	if u.Hostname() == "" {
+		return "", fmt.Errorf("trustpilot: no domain in %q", website)
 	}
-	return bs, nil
+	return strings.ToLower(u.Hostname()), nil
 }
 
 //ServiceReviews ...
 type ServiceReviews struct {
 	Reviews []*SingleServiceReview
+	Links   []*Links `json:"links"`
 }
 
+func (sr *ServiceReviews) len() int         { return len(sr.Reviews) }
+func (sr *ServiceReviews) nextPage() string { return NextPageURL(sr.Links) }
+
 //SingleServiceReview ...
 type SingleServiceReview struct {
 	Title        *string       `json:"title"`
@@ -69,9 +149,20 @@ type SingleServiceReview struct {
 	UpdatedAt    *string       `json:"updatedAt"`
 	CreatedAt    *string       `json:"createdAt"`
 	Stars        *int          `json:"stars"`
+	Language     *string       `json:"language"`
 	BusinessUnit *BusinessUnit `json:"businessUnit"`
 	ID           *string       `json:"id"`
 	Consumer     *BusinessUnit `json:"consumer"`
+	CompanyReply *CompanyReply `json:"companyReply"`
+}
+
+//CompanyReply is the reply of the business to a review, as part of the review
+type CompanyReply struct {
+	Text                   *string `json:"text"`
+	AuthorBusinessUserID   *string `json:"authorBusinessUserId"`
+	AuthorBusinessUserName *string `json:"authorBusinessUserName"`
+	CreatedAt              *string `json:"createdAt"`
+	UpdatedAt              *string `json:"updatedAt"`
 }
 
 //BusinessUnit ...
@@ -80,34 +171,198 @@ type BusinessUnit struct {
 	ID         *string `json:"id"`
 }
 
+// LatestReviewsOptions specifies the optional parameters to the
+// BusinessService.GetServiceReviews method.
+type LatestReviewsOptions struct {
+	// Count is the number of reviews to return, at most 100.
+	Count int `url:"count,omitempty"`
+
+	// Language only returns reviews written in this language, e.g. "en".
+	Language string `url:"language,omitempty"`
+
+	// Locale is the locale the reviews are presented in, e.g. "en-US".
+	Locale string `url:"locale,omitempty"`
+}
+
 //GetServiceReviews gets latest reviews by language
 //This method gets the latest reviews written in a specfic language.
 //
 //https://developers.trustpilot.com/service-reviews-api#get-latest-reviews-by-language
-func (b *BusinessService) GetServiceReviews(count int) (*ServiceReviews, error) {
-	u := fmt.Sprintf("%s/reviews/latest", fakeURL)
-	if isTEST {
-		u = fmt.Sprintf("/v1/reviews/latest")
+func (b *BusinessService) GetServiceReviews(ctx context.Context, opts *LatestReviewsOptions) (*ServiceReviews, *Response, error) {
+	u, err := addOptions("reviews/latest", opts)
+	if err != nil {
+		return nil, nil, err
 	}
 	sr := new(ServiceReviews)
 	req, err := b.client.NewRequest("GET", u, nil)
 	if err != nil {
-		log.Printf("Err %v", err)
-		return sr, err
+		return nil, nil, err
 	}
 
 	req.Header.Add("Authorization", ""+b.client.ClientID)
-	resp, err := b.client.Do(b.client.CTX, req)
+	resp, err := b.client.Do(ctx, req, sr)
 	if err != nil {
-		log.Printf("Err1 %v", err)
-		return sr, err
+		return nil, resp, err
 	}
-	err = json.Unmarshal(resp, &sr) // convert the response data to json
+	return sr, resp, nil
+}
+
+// LatestReviewsIterator follows the latest reviews feed, yielding every
+// review once. See BusinessService.LatestReviews.
+type LatestReviewsIterator struct {
+	service *BusinessService
+	opts    *LatestReviewsOptions
+
+	seen    map[string]bool // IDs in the last fetched feed
+	fetched bool            // whether buf was filled by the current round
+	buf     []*SingleServiceReview
+	cur     *SingleServiceReview
+	resp    *Response
+	err     error
+}
+
+// LatestReviews returns an iterator over the latest reviews feed. Next
+// fetches the feed once, yields the reviews that were not yielded before and
+// then reports false. As new reviews keep coming in, Next can be called again
+// later to fetch the feed again. A failed fetch only ends its round, so
+// polling carries on without yielding reviews twice:
+//
+//	it := client.Business.LatestReviews(&trustpilot.LatestReviewsOptions{Language: "en"})
+//	for {
+//		for it.Next(ctx) {
+//			show(it.Review())
+//		}
+//		if err := it.Err(); err != nil {
+//			log.Printf("fetching latest reviews: %v", err)
+//		}
+//		time.Sleep(time.Minute)
+//	}
+func (b *BusinessService) LatestReviews(opts *LatestReviewsOptions) *LatestReviewsIterator {
+	return &LatestReviewsIterator{service: b, opts: opts}
+}
+
+// Next advances to the next review, fetching the feed if needed. It returns
+// false when there are no new reviews or an error occurred; the next call
+// then starts a new round and fetches the feed again.
+func (it *LatestReviewsIterator) Next(ctx context.Context) bool {
+	it.cur = nil
+	if len(it.buf) == 0 {
+		if it.fetched {
+			// This round's reviews are used up; fetch again on the next call.
+			it.fetched = false
+			return false
+		}
+		it.fetched = true
+		it.err = nil
+		sr, resp, err := it.service.GetServiceReviews(ctx, it.opts)
+		it.resp = resp
+		if err != nil {
+			// End the round, keeping seen for the next one.
+			it.fetched = false
+			it.err = err
+			return false
+		}
+		seen := make(map[string]bool, len(sr.Reviews))
+		for _, r := range sr.Reviews {
+			id := StringValue(r.ID)
+			seen[id] = true
+			if !it.seen[id] {
+				it.buf = append(it.buf, r)
+			}
+		}
+		it.seen = seen
+	}
+	if len(it.buf) == 0 {
+		it.fetched = false
+		return false
+	}
+	it.cur, it.buf = it.buf[0], it.buf[1:]
+	return true
+}
+
+// Review returns the current review.
+func (it *LatestReviewsIterator) Review() *SingleServiceReview { return it.cur }
 
+// Response returns the response of the last fetch.
+func (it *LatestReviewsIterator) Response() *Response { return it.resp }
+
+// Err returns the error that ended the last round, if any.
+func (it *LatestReviewsIterator) Err() error { return it.err }
+
+// Review orderings accepted by BusinessUnitReviewsOptions.OrderBy.
+const (
+	OrderByCreatedAtAsc  = "createdat.asc"
+	OrderByCreatedAtDesc = "createdat.desc"
+	OrderByStarsAsc      = "stars.asc"
+	OrderByStarsDesc     = "stars.desc"
+)
+
+// BusinessUnitReviewsOptions specifies the optional parameters to the
+// BusinessService.ListBusinessUnitReviews method.
+type BusinessUnitReviewsOptions struct {
+	// Stars only returns reviews with these star ratings, 1 to 5.
+	Stars []int `url:"stars,omitempty,comma"`
+
+	// Language only returns reviews in these languages, e.g. "da" or "en".
+	// "all" returns reviews in every language.
+	Language []string `url:"language,omitempty,comma"`
+
+	// TagGroup and TagValue only return reviews tagged with this tag.
+	TagGroup string `url:"tagGroup,omitempty"`
+	TagValue string `url:"tagValue,omitempty"`
+
+	// StartDateTime and EndDateTime only return reviews created in between.
+	StartDateTime time.Time `url:"startDateTime,omitempty"`
+	EndDateTime   time.Time `url:"endDateTime,omitempty"`
+
+	// OrderBy is one of the OrderBy constants.
+	OrderBy string `url:"orderBy,omitempty"`
+
+	// Responded only returns reviews the business has replied to if true,
+	// and reviews without a reply if false.
+	Responded *bool `url:"responded,omitempty"`
+
+	// IncludeReportedReviews includes reviews reported by the business.
+	IncludeReportedReviews bool `url:"includeReportedReviews,omitempty"`
+
+	ListOptions
+}
+
+//ListBusinessUnitReviews Get a business unit's reviews
+//This method gets the public reviews of a business unit, filtered and paginated according to opts.
+//
+//https://developers.trustpilot.com/business-units-api#get-a-business-unit's-reviews
+func (b *BusinessService) ListBusinessUnitReviews(ctx context.Context, businessUnitID string, opts *BusinessUnitReviewsOptions) (*ServiceReviews, *Response, error) {
+	u := fmt.Sprintf("business-units/%s/reviews", businessUnitID)
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	sr := new(ServiceReviews)
+	req, err := b.client.NewRequest("GET", u, nil)
 	if err != nil {
-		return sr, err
+		return nil, nil, err
 	}
-	return sr, nil
+
+	req.Header.Add("Authorization", ""+b.client.ClientID)
+	resp, err := b.client.Do(ctx, req, sr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return sr, resp, nil
+}
+
+// BusinessUnitReviews returns an iterator over the reviews of a business
+// unit, walking all pages of ListBusinessUnitReviews from the page given in
+// opts.
+func (b *BusinessService) BusinessUnitReviews(businessUnitID string, opts *BusinessUnitReviewsOptions) *ServiceReviewIterator {
+	u := fmt.Sprintf("business-units/%s/reviews", businessUnitID)
+	u, err := addOptions(u, opts)
+	it := &ServiceReviewIterator{newIterator(b.client, u, func() page { return new(ServiceReviews) }, func(req *http.Request) {
+		req.Header.Add("Authorization", ""+b.client.ClientID)
+	})}
+	it.err = err
+	return it
 }
 
 //GetServicePrivateReview Get private review
@@ -115,44 +370,181 @@ func (b *BusinessService) GetServiceReviews(count int) (*ServiceReviews, error)
 //and status as either "active" or "reported".
 //
 //https://developers.trustpilot.com/service-reviews-api#get-private-review
-func (b *BusinessService) GetServicePrivateReview(token, reviewID string) (*SingleServiceReview, error) {
-	u := fmt.Sprintf("%s/private/reviews/%s", fakeURL, reviewID)
-	if isTEST {
-		u = fmt.Sprintf("/private/reviews/507f191e810c19729de860ea")
-	}
-	sr := new(SingleServiceReview)
+func (b *BusinessService) GetServicePrivateReview(ctx context.Context, reviewID string) (*PrivateServiceReview, *Response, error) {
+	u := fmt.Sprintf("private/reviews/%s", reviewID)
+	sr := new(PrivateServiceReview)
 	req, err := b.client.NewRequest("GET", u, nil)
 	if err != nil {
-		log.Printf("Err %v", err)
-		return sr, err
+		return nil, nil, err
 	}
-
-	req.Header.Add("Authorization", ""+token)
-	resp, err := b.client.Do(b.client.CTX, req)
+	resp, err := b.client.Do(ctx, req, sr)
 	if err != nil {
-		log.Printf("Err1 %v", err)
-		return sr, err
+		return nil, resp, err
 	}
-	err = json.Unmarshal(resp, &sr) // convert the response data to json
+	return sr, resp, nil
+}
+
+// Statuses of a private service review.
+const (
+	ReviewStatusActive   = "active"
+	ReviewStatusReported = "reported"
+	ReviewStatusRemoved  = "removed"
+)
+
+// Values of PrivateBusinessUnitReviewsOptions.FindReviewer.
+const (
+	FindReviewerRequested    = "requested"
+	FindReviewerNotRequested = "notRequested"
+)
+
+//PrivateServiceReview is a service review as seen by the business it was written for,
+//including the reference it was invited with and its status.
+type PrivateServiceReview struct {
+	Title          *string       `json:"title"`
+	Text           *string       `json:"text"`
+	UpdatedAt      *string       `json:"updatedAt"`
+	CreatedAt      *string       `json:"createdAt"`
+	Stars          *int          `json:"stars"`
+	Language       *string       `json:"language"`
+	BusinessUnit   *BusinessUnit `json:"businessUnit"`
+	ID             *string       `json:"id"`
+	Consumer       *BusinessUnit `json:"consumer"`
+	ReferenceID    *string       `json:"referenceId"`
+	ReferenceEmail *string       `json:"referralEmail"`
+	Status         *string       `json:"status"`
+	IsVerified     *bool         `json:"isVerified"`
+	FindReviewer   *FindReviewer `json:"findReviewer"`
+	CompanyReply   *CompanyReply `json:"companyReply"`
+	Tags           []*Tag        `json:"tags"`
+}
+
+//FindReviewer tells whether the business may ask the consumer behind a review to identify themselves,
+//and the requests it made so far.
+type FindReviewer struct {
+	IsEligible *bool                  `json:"isEligible"`
+	Requests   []*FindReviewerRequest `json:"requests"`
+}
+
+// Statuses a FindReviewerRequest goes through. A request starts as
+// requested and ends when the consumer responds or declines, or when it
+// expires unanswered.
+const (
+	FindReviewerRequestStatusRequested         = "requested"
+	FindReviewerRequestStatusConsumerResponded = "consumerResponded"
+	FindReviewerRequestStatusConsumerDeclined  = "consumerDeclined"
+	FindReviewerRequestStatusExpired           = "expired"
+)
+
+//FindReviewerRequest is a request to the consumer behind a review to identify themselves.
+type FindReviewerRequest struct {
+	ID       *string `json:"id"`
+	Status   *string `json:"status"`
+	Message  *string `json:"message"`
+	Created  *string `json:"created"`
+	Modified *string `json:"modified"`
+}
+
+//PrivateServiceReviews ...
+type PrivateServiceReviews struct {
+	Reviews []*PrivateServiceReview
+	Links   []*Links `json:"links"`
+}
+
+func (sr *PrivateServiceReviews) len() int         { return len(sr.Reviews) }
+func (sr *PrivateServiceReviews) nextPage() string { return NextPageURL(sr.Links) }
+
+// PrivateBusinessUnitReviewsOptions specifies the optional parameters to the
+// BusinessService.ListPrivateBusinessUnitReviews method.
+type PrivateBusinessUnitReviewsOptions struct {
+	// ReferenceID only returns the reviews invited with these reference IDs,
+	// typically order numbers.
+	ReferenceID []string `url:"referenceId,omitempty,comma"`
+
+	// ReferenceEmail only returns the reviews invited with this email.
+	ReferenceEmail string `url:"referralEmail,omitempty"`
+
+	// Status only returns reviews with these statuses, see the ReviewStatus
+	// constants.
+	Status []string `url:"status,omitempty,comma"`
 
+	// FindReviewer is one of the FindReviewer constants.
+	FindReviewer string `url:"findReviewer,omitempty"`
+
+	// Stars only returns reviews with these star ratings, 1 to 5.
+	Stars []int `url:"stars,omitempty,comma"`
+
+	// Language only returns reviews in these languages, e.g. "da" or "en".
+	Language []string `url:"language,omitempty,comma"`
+
+	// StartDateTime and EndDateTime only return reviews created in between.
+	StartDateTime time.Time `url:"startDateTime,omitempty"`
+	EndDateTime   time.Time `url:"endDateTime,omitempty"`
+
+	// OrderBy is one of the OrderBy constants.
+	OrderBy string `url:"orderBy,omitempty"`
+
+	// Responded only returns reviews the business has replied to if true,
+	// and reviews without a reply if false.
+	Responded *bool `url:"responded,omitempty"`
+
+	// TagGroup and TagValue only return reviews tagged with this tag.
+	TagGroup string `url:"tagGroup,omitempty"`
+	TagValue string `url:"tagValue,omitempty"`
+
+	// IgnoreTagValueCase matches TagValue regardless of case.
+	IgnoreTagValueCase bool `url:"ignoreTagValueCase,omitempty"`
+
+	ListOptions
+}
+
+//ListPrivateBusinessUnitReviews Get private reviews for a business unit
+//This method gets the reviews of a business unit together with their private information (referenceId and
+//referralEmail), status and find reviewer requests, filtered and paginated according to opts.
+//
+//https://developers.trustpilot.com/business-units-api#business-unit-private-reviews
+func (b *BusinessService) ListPrivateBusinessUnitReviews(ctx context.Context, businessUnitID string, opts *PrivateBusinessUnitReviewsOptions) (*PrivateServiceReviews, *Response, error) {
+	u := fmt.Sprintf("private/business-units/%s/reviews", businessUnitID)
+	u, err := addOptions(u, opts)
 	if err != nil {
-		return sr, err
+		return nil, nil, err
 	}
-	return sr, nil
+	sr := new(PrivateServiceReviews)
+	req, err := b.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := b.client.Do(ctx, req, sr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return sr, resp, nil
 }
 
-//ServiceReviewResp decode the response from api
-type ServiceReviewResp struct{}
+// PrivateBusinessUnitReviews returns an iterator over the private reviews of
+// a business unit, walking all pages of ListPrivateBusinessUnitReviews from
+// the page given in opts.
+func (b *BusinessService) PrivateBusinessUnitReviews(businessUnitID string, opts *PrivateBusinessUnitReviewsOptions) *PrivateServiceReviewIterator {
+	u := fmt.Sprintf("private/business-units/%s/reviews", businessUnitID)
+	u, err := addOptions(u, opts)
+	it := &PrivateServiceReviewIterator{newIterator(b.client, u, func() page { return new(PrivateServiceReviews) }, nil)}
+	it.err = err
+	return it
+}
+
+//ServiceReviewResp is the reply of the business to a review as stored by the api
+type ServiceReviewResp struct {
+	Message   *string `json:"message"`
+	CreatedAt *string `json:"createdAt"`
+	UpdatedAt *string `json:"updatedAt"`
+}
 
 //SendServiceReviews Reply to a review.
-//This method will post a reply to a review.
+//This method will post a reply to a review. The API answers 201 Created without a body, so only Message is set
+//in the returned ServiceReviewResp; use UpsertServiceReviewReply to get the reply as stored, with its times.
 //
 //https://developers.trustpilot.com/service-reviews-api#reply-to-a-review-
-func (b *BusinessService) SendServiceReviews(token, reviewID, message string) (*ServiceReviewResp, error) {
-	u := fmt.Sprintf("%s/private/reviews/%s/reply", fakeURL, reviewID)
-	if isTEST {
-		u = fmt.Sprintf("/private/reviews/507f191e810c19729de860ea/reply")
-	}
+func (b *BusinessService) SendServiceReviews(ctx context.Context, reviewID, message string) (*ServiceReviewResp, *Response, error) {
+	u := fmt.Sprintf("private/reviews/%s/reply", reviewID)
 	sr := new(ServiceReviewResp)
 	reqBody := &struct {
 		Message string `json:"message"`
@@ -160,20 +552,206 @@ func (b *BusinessService) SendServiceReviews(token, reviewID, message string) (*
 
 	req, err := b.client.NewRequest("POST", u, reqBody)
 	if err != nil {
-		log.Printf("Err %v", err)
-		return sr, err
+		return nil, nil, err
+	}
+	resp, err := b.client.Do(ctx, req, sr)
+	if err != nil {
+		return nil, resp, err
+	}
+	if sr.Message == nil {
+		sr.Message = &message
+	}
+	return sr, resp, nil
+}
+
+//UpsertServiceReviewReply Create or update the reply to a review
+//This method posts message as the reply to a review, replacing any earlier reply, and then gets the review
+//to return the reply as stored, including when it was created and last updated.
+//
+//https://developers.trustpilot.com/service-reviews-api#reply-to-a-review-
+func (b *BusinessService) UpsertServiceReviewReply(ctx context.Context, reviewID, message string) (*ServiceReviewResp, *Response, error) {
+	if _, resp, err := b.SendServiceReviews(ctx, reviewID, message); err != nil {
+		return nil, resp, err
+	}
+	sr, resp, err := b.GetServicePrivateReview(ctx, reviewID)
+	if err != nil {
+		return nil, resp, err
+	}
+	if sr.CompanyReply == nil {
+		return nil, resp, fmt.Errorf("trustpilot: review %s has no reply after posting it", reviewID)
+	}
+	return &ServiceReviewResp{
+		Message:   sr.CompanyReply.Text,
+		CreatedAt: sr.CompanyReply.CreatedAt,
+		UpdatedAt: sr.CompanyReply.UpdatedAt,
+	}, resp, nil
+}
+
+//DeleteServiceReviewReply Delete the reply to a review
+//This method deletes the reply of the business to a review.
+//
+//https://developers.trustpilot.com/service-reviews-api#delete-reply-to-a-review
+func (b *BusinessService) DeleteServiceReviewReply(ctx context.Context, reviewID string) (*Response, error) {
+	u := fmt.Sprintf("private/reviews/%s/reply", reviewID)
+	req, err := b.client.NewRequest("DELETE", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	return b.client.Do(ctx, req, nil)
+}
+
+//Tag is a label a business puts on its reviews, a value within a group
+type Tag struct {
+	Group *string `json:"group"`
+	Value *string `json:"value"`
+}
+
+// tagList is the request and response body of the tag endpoints.
+type tagList struct {
+	Tags []*Tag `json:"tags"`
+}
+
+//ListServiceReviewTags Get tags of a review
+//This method gets the tags the business put on a review.
+//
+//https://developers.trustpilot.com/service-reviews-api#get-tags-of-a-review
+func (b *BusinessService) ListServiceReviewTags(ctx context.Context, reviewID string) ([]*Tag, *Response, error) {
+	u := fmt.Sprintf("private/reviews/%s/tags", reviewID)
+	return b.tags(ctx, "GET", u, nil)
+}
+
+//AddServiceReviewTags Add tags to a review
+//This method adds tags to a review, keeping the tags it already has.
+//
+//https://developers.trustpilot.com/service-reviews-api#add-tags-to-a-review
+func (b *BusinessService) AddServiceReviewTags(ctx context.Context, reviewID string, tags []*Tag) (*Response, error) {
+	u := fmt.Sprintf("private/reviews/%s/tags", reviewID)
+	_, resp, err := b.tags(ctx, "POST", u, &tagList{Tags: tags})
+	return resp, err
+}
+
+//ReplaceServiceReviewTags Replace the tags of a review
+//This method sets the tags of a review, removing all others. An empty list removes every tag.
+//
+//https://developers.trustpilot.com/service-reviews-api#set-tags-of-a-review
+func (b *BusinessService) ReplaceServiceReviewTags(ctx context.Context, reviewID string, tags []*Tag) (*Response, error) {
+	u := fmt.Sprintf("private/reviews/%s/tags", reviewID)
+	if tags == nil {
+		tags = []*Tag{}
+	}
+	_, resp, err := b.tags(ctx, "PUT", u, &tagList{Tags: tags})
+	return resp, err
+}
+
+//RemoveServiceReviewTags Remove tags from a review
+//This method removes the given tags from a review, keeping its other tags.
+//
+//https://developers.trustpilot.com/service-reviews-api#delete-tags-of-a-review
+func (b *BusinessService) RemoveServiceReviewTags(ctx context.Context, reviewID string, tags []*Tag) (*Response, error) {
+	u := fmt.Sprintf("private/reviews/%s/tags", reviewID)
+	_, resp, err := b.tags(ctx, "DELETE", u, &tagList{Tags: tags})
+	return resp, err
+}
+
+//ListBusinessUnitTags Get all tags of a business unit
+//This method gets every tag the business unit has put on any of its reviews.
+//
+//https://developers.trustpilot.com/business-units-api#get-all-tags-of-a-business-unit
+func (b *BusinessService) ListBusinessUnitTags(ctx context.Context, businessUnitID string) ([]*Tag, *Response, error) {
+	u := fmt.Sprintf("private/business-units/%s/tags", businessUnitID)
+	return b.tags(ctx, "GET", u, nil)
+}
+
+// tags sends a request to a tag endpoint and decodes the tags it returns.
+func (b *BusinessService) tags(ctx context.Context, method, u string, body *tagList) ([]*Tag, *Response, error) {
+	var reqBody interface{}
+	if body != nil {
+		reqBody = body
+	}
+	req, err := b.client.NewRequest(method, u, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	tl := new(tagList)
+	resp, err := b.client.Do(ctx, req, tl)
+	if err != nil {
+		return nil, resp, err
+	}
+	return tl.Tags, resp, nil
+}
+
+// Reasons accepted by BusinessService.ReportServiceReview.
+const (
+	ReportReasonPersonalInformation = "containsPersonalInformation"
+	ReportReasonOffensive           = "offensiveOrAbusive"
+	ReportReasonAdvertising         = "advertisingOrPromotional"
+	ReportReasonNotAnExperience     = "notAboutGenuineExperience"
+	ReportReasonOther               = "other"
+)
+
+//ReviewReport is a report of a review to Trustpilot
+type ReviewReport struct {
+	ReportID *string `json:"reportId"`
+}
+
+//ReportServiceReview Report a review
+//This method reports a review to Trustpilot for breaking its guidelines. reason is one of the ReportReason
+//constants and comment explains it to the Trustpilot content integrity team.
+//
+//https://developers.trustpilot.com/service-reviews-api#report-a-review
+func (b *BusinessService) ReportServiceReview(ctx context.Context, reviewID, reason, comment string) (*ReviewReport, *Response, error) {
+	u := fmt.Sprintf("private/reviews/%s/report", reviewID)
+	rr := new(ReviewReport)
+	reqBody := &struct {
+		Reason        string `json:"reason"`
+		ReasonComment string `json:"reasonComment,omitempty"`
+	}{Reason: reason, ReasonComment: comment}
+
+	req, err := b.client.NewRequest("POST", u, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := b.client.Do(ctx, req, rr)
+	if err != nil {
+		return nil, resp, err
 	}
+	return rr, resp, nil
+}
+
+//CreateFindReviewerRequest Ask the consumer behind a review to identify themselves
+//This method creates a find reviewer request for a review, sending message to the consumer. The review must be
+//eligible, see PrivateServiceReview.FindReviewer.
+//
+//https://developers.trustpilot.com/service-reviews-api#create-find-reviewer-request
+func (b *BusinessService) CreateFindReviewerRequest(ctx context.Context, reviewID, message string) (*FindReviewerRequest, *Response, error) {
+	u := fmt.Sprintf("private/reviews/%s/find-reviewer", reviewID)
+	fr := new(FindReviewerRequest)
+	reqBody := &struct {
+		Message string `json:"message"`
+	}{Message: message}
 
-	req.Header.Add("Authorization", ""+token)
-	resp, err := b.client.Do(b.client.CTX, req)
+	req, err := b.client.NewRequest("POST", u, reqBody)
 	if err != nil {
-		log.Printf("Err1 %v", err)
-		return sr, err
+		return nil, nil, err
 	}
-	err = json.Unmarshal(resp, &sr)
+	resp, err := b.client.Do(ctx, req, fr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return fr, resp, nil
+}
 
+//ListFindReviewerRequests Get the find reviewer requests of a review
+//This method gets the private review and returns the find reviewer requests made for it, with their status.
+//
+//https://developers.trustpilot.com/service-reviews-api#get-private-review
+func (b *BusinessService) ListFindReviewerRequests(ctx context.Context, reviewID string) ([]*FindReviewerRequest, *Response, error) {
+	sr, resp, err := b.GetServicePrivateReview(ctx, reviewID)
 	if err != nil {
-		return sr, err
+		return nil, resp, err
+	}
+	if sr.FindReviewer == nil {
+		return nil, resp, nil
 	}
-	return sr, nil
+	return sr.FindReviewer.Requests, resp, nil
 }
@@ -10,6 +10,7 @@ import (
 	"os"
 	"reflect"
 	"testing"
+	"time"
 )
 
 var (
@@ -83,14 +84,14 @@ var (
 							"width":  "<Image width>",
 							"height": "<Image height>",
 						},
-						"displayName": "John Doe",
-						"id":          "507f191e810c19729de860ea",
-						"links": []map[string]interface{}{
-							{
-								"href":   "<Url for the resource>",
-								"method": "<Http method for the resource>",
-								"rel":    "<Description of the relation>",
-							},
+					},
+					"displayName": "John Doe",
+					"id":          "507f191e810c19729de860ea",
+					"links": []map[string]interface{}{
+						{
+							"href":   "<Url for the resource>",
+							"method": "<Http method for the resource>",
+							"rel":    "<Description of the relation>",
 						},
 					},
 				},
@@ -193,13 +194,14 @@ func TestBusiness_getCrededential(t *testing.T) {
 	mux.HandleFunc("/business-units/find", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "GET")
 		testQueryParams(t, r, "name", "Trustpilot")
-		testHeader(t, r, "Authorization", "eHh4eHh4eDp4eHh4eHh4")
+		testHeader(t, r, "Authorization", "xxxxxxx")
 		fmt.Fprint(w, `{"displayName": "Trustpilot","id": "507f191e810c19729de860ea"}`)
 	})
-	client.CTX = ctx
 	client.ClientID = "xxxxxxx"
 	client.ClientSecret = "xxxxxxx"
-	got, err := client.Business.GetBusinessCredentials("eHh4eHh4eDp4eHh4eHh4", "Trustpilot")
+	// The API key is sent even when a token is available.
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("eHh4eHh4eDp4eHh4eHh4")})
+	got, _, err := client.Business.GetBusinessCredentials(ctx, "Trustpilot")
 	if err != nil {
 		t.Errorf("TestBusiness_getCrededential returned error: %v", err)
 	}
@@ -209,21 +211,102 @@ func TestBusiness_getCrededential(t *testing.T) {
 	}
 }
 
+const businessUnitJSON = `{
+	"id": "507f191e810c19729de860ea",
+	"displayName": "Trustpilot",
+	"name": {"identifying": "trustpilot.com", "referring": ["trustpilot.com", "www.trustpilot.com"]},
+	"websiteUrl": "http://www.trustpilot.com",
+	"country": "DK",
+	"status": "active",
+	"score": {"trustScore": 9.1, "stars": 4.5},
+	"numberOfReviews": {"total": 12, "usedForTrustScoreCalculation": 10, "oneStar": 1, "twoStars": 1, "threeStars": 2, "fourStars": 3, "fiveStars": 5}
+}`
+
+var wantBusinessUnit = &Business{
+	ID:          String("507f191e810c19729de860ea"),
+	DisplayName: String("Trustpilot"),
+	Name:        &BusinessName{Identifying: String("trustpilot.com"), Referring: []string{"trustpilot.com", "www.trustpilot.com"}},
+	WebsiteURL:  String("http://www.trustpilot.com"),
+	Country:     String("DK"),
+	Status:      String("active"),
+	Score:       &BusinessScore{TrustScore: Float64(9.1), Stars: Float64(4.5)},
+	NumberOfReviews: &NumberOfReviews{
+		Total:                        Int(12),
+		UsedForTrustScoreCalculation: Int(10),
+		OneStar:                      Int(1),
+		TwoStars:                     Int(1),
+		ThreeStars:                   Int(2),
+		FourStars:                    Int(3),
+		FiveStars:                    Int(5),
+	},
+}
+
+func TestBusiness_getBusinessUnit(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/business-units/507f191e810c19729de860ea", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "xxxxxxx")
+		fmt.Fprint(w, businessUnitJSON)
+	})
+	client.ClientID = "xxxxxxx"
+	got, _, err := client.Business.GetBusinessUnit(ctx, "507f191e810c19729de860ea")
+	if err != nil {
+		t.Errorf("TestBusiness_getBusinessUnit returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, wantBusinessUnit) {
+		t.Errorf("TestBusiness_getBusinessUnit returned %+v, want %+v", got, wantBusinessUnit)
+	}
+}
+
+func TestBusiness_getBusinessUnitByDomain(t *testing.T) {
+	for _, domain := range []string{"trustpilot.com", "https://Trustpilot.com/about?x=1", " trustpilot.com:443 "} {
+		client, mux, _, teardown := bsetup()
+		mux.HandleFunc("/business-units/find", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			testQueryParams(t, r, "name", "trustpilot.com")
+			testHeader(t, r, "Authorization", "xxxxxxx")
+			fmt.Fprint(w, businessUnitJSON)
+		})
+		client.ClientID = "xxxxxxx"
+		got, _, err := client.Business.GetBusinessUnitByDomain(ctx, domain)
+		teardown()
+		if err != nil {
+			t.Errorf("GetBusinessUnitByDomain(%q) returned error: %v", domain, err)
+		}
+		if !reflect.DeepEqual(got, wantBusinessUnit) {
+			t.Errorf("GetBusinessUnitByDomain(%q) returned %+v, want %+v", domain, got, wantBusinessUnit)
+		}
+	}
+
+	client := NewClient(nil)
+	if _, _, err := client.Business.GetBusinessUnitByDomain(ctx, "https:///path"); err == nil {
+		t.Errorf("GetBusinessUnitByDomain returned no error for a URL without host")
+	}
+}
+
 func TestBusiness_getServiceReviews(t *testing.T) {
 	client, mux, _, teardown := bsetup()
 	defer teardown()
 	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
 		testMethod(t, r, "GET")
 		testHeader(t, r, "Authorization", "xxxxxxx")
+		testFormValues(t, r, values{"count": "3", "language": "da", "locale": "da-DK"})
+		w.Header().Set(headerRateLimit, "833")
+		w.Header().Set(headerRateRemaining, "832")
+		w.Header().Set(headerRateReset, "1372700873")
 		fmt.Fprint(w, respStr)
 	})
-	client.CTX = ctx
 	client.ClientID = "xxxxxxx"
 	client.ClientSecret = "xxxxxxx"
-	got, err := client.Business.GetServiceReviews(3)
+	got, resp, err := client.Business.GetServiceReviews(ctx, &LatestReviewsOptions{Count: 3, Language: "da", Locale: "da-DK"})
 	if err != nil {
 		t.Errorf("TestBusiness_getServiceReviews returned error: %v", err)
 	}
+	wantRate := Rate{Limit: 833, Remaining: 832, Reset: Timestamp{time.Unix(1372700873, 0)}}
+	if resp.StatusCode != http.StatusOK || !reflect.DeepEqual(resp.Rate, wantRate) {
+		t.Errorf("TestBusiness_getServiceReviews returned response %d %+v, want %d %+v", resp.StatusCode, resp.Rate, http.StatusOK, wantRate)
+	}
 	wantSR := new(ServiceReviews)
 	bytes, _ := json.Marshal(&serviceReviews)
 	_ = json.Unmarshal(bytes, &wantSR)
@@ -233,6 +316,169 @@ func TestBusiness_getServiceReviews(t *testing.T) {
 	}
 }
 
+func TestBusiness_listBusinessUnitReviews(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/business-units/507f191e810c19729de860ea/reviews", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "xxxxxxx")
+		testFormValues(t, r, values{
+			"stars":                  "4,5",
+			"language":               "da,en",
+			"tagGroup":               "team",
+			"tagValue":               "support",
+			"startDateTime":          "2013-09-07T13:37:00",
+			"orderBy":                "createdat.desc",
+			"responded":              "false",
+			"includeReportedReviews": "true",
+			"page":                   "2",
+			"perPage":                "50",
+		})
+		fmt.Fprint(w, respStr)
+	})
+	client.ClientID = "xxxxxxx"
+	opts := &BusinessUnitReviewsOptions{
+		Stars:                  []int{4, 5},
+		Language:               []string{"da", "en"},
+		TagGroup:               "team",
+		TagValue:               "support",
+		StartDateTime:          time.Date(2013, 9, 7, 13, 37, 0, 0, time.UTC),
+		OrderBy:                OrderByCreatedAtDesc,
+		Responded:              Bool(false),
+		IncludeReportedReviews: true,
+		ListOptions:            ListOptions{Page: 2, PerPage: 50},
+	}
+	got, _, err := client.Business.ListBusinessUnitReviews(ctx, "507f191e810c19729de860ea", opts)
+	if err != nil {
+		t.Errorf("ListBusinessUnitReviews returned error: %v", err)
+	}
+	wantSR := new(ServiceReviews)
+	bytes, _ := json.Marshal(&serviceReviews)
+	_ = json.Unmarshal(bytes, &wantSR)
+	if !reflect.DeepEqual(got, wantSR) {
+		t.Errorf("ListBusinessUnitReviews returned %+v, want %+v", got, wantSR)
+	}
+}
+
+func TestBusiness_listPrivateBusinessUnitReviews(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/business-units/507f191e810c19729de860ea/reviews", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "Bearer t0k3n")
+		testFormValues(t, r, values{
+			"referenceId":   "order-1,order-2",
+			"referralEmail": "jane@example.com",
+			"status":        "active,reported",
+			"findReviewer":  "notRequested",
+			"tagGroup":      "team",
+			"tagValue":      "support",
+			"perPage":       "100",
+		})
+		fmt.Fprint(w, `{"reviews":[{"id":"r1","stars":5,"referenceId":"order-1","referralEmail":"jane@example.com","status":"active",`+
+			`"findReviewer":{"isEligible":true,"requests":[{"id":"f1","status":"requested","created":"2013-09-07T13:37:00Z"}]}}],"links":[]}`)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("t0k3n")})
+	opts := &PrivateBusinessUnitReviewsOptions{
+		ReferenceID:    []string{"order-1", "order-2"},
+		ReferenceEmail: "jane@example.com",
+		Status:         []string{ReviewStatusActive, ReviewStatusReported},
+		FindReviewer:   FindReviewerNotRequested,
+		TagGroup:       "team",
+		TagValue:       "support",
+		ListOptions:    ListOptions{PerPage: 100},
+	}
+	got, _, err := client.Business.ListPrivateBusinessUnitReviews(ctx, "507f191e810c19729de860ea", opts)
+	if err != nil {
+		t.Errorf("ListPrivateBusinessUnitReviews returned error: %v", err)
+	}
+	want := &PrivateServiceReviews{
+		Reviews: []*PrivateServiceReview{{
+			ID:             String("r1"),
+			Stars:          Int(5),
+			ReferenceID:    String("order-1"),
+			ReferenceEmail: String("jane@example.com"),
+			Status:         String("active"),
+			FindReviewer: &FindReviewer{
+				IsEligible: Bool(true),
+				Requests:   []*FindReviewerRequest{{ID: String("f1"), Status: String("requested"), Created: String("2013-09-07T13:37:00Z")}},
+			},
+		}},
+		Links: []*Links{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListPrivateBusinessUnitReviews returned %+v, want %+v", got, want)
+	}
+}
+
+func TestBusiness_latestReviews(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	feeds := []string{
+		`{"reviews":[{"id":"r2"},{"id":"r1"}]}`,
+		`{"reviews":[{"id":"r2"},{"id":"r1"}]}`,
+		`{"reviews":[{"id":"r3"},{"id":"r2"}]}`,
+	}
+	var call int
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		testFormValues(t, r, values{"count": "2", "language": "en"})
+		fmt.Fprint(w, feeds[call])
+		call++
+	})
+
+	it := client.Business.LatestReviews(&LatestReviewsOptions{Count: 2, Language: "en"})
+	var got []string
+	for poll := 0; poll < 3; poll++ {
+		for it.Next(ctx) {
+			got = append(got, StringValue(it.Review().ID))
+		}
+		if err := it.Err(); err != nil {
+			t.Fatalf("LatestReviewsIterator returned error: %v", err)
+		}
+	}
+	if want := []string{"r2", "r1", "r3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LatestReviewsIterator yielded %v, want %v", got, want)
+	}
+}
+
+func TestBusiness_latestReviews_resumesAfterError(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	var call int
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		call++
+		switch call {
+		case 1:
+			fmt.Fprint(w, `{"reviews":[{"id":"r1"}]}`)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, `{"reviews":[{"id":"r2"},{"id":"r1"}]}`)
+		}
+	})
+
+	it := client.Business.LatestReviews(nil)
+	var got []string
+	var errs int
+	for poll := 0; poll < 3; poll++ {
+		for it.Next(ctx) {
+			got = append(got, StringValue(it.Review().ID))
+		}
+		if it.Err() != nil {
+			errs++
+		}
+	}
+	if errs != 1 {
+		t.Errorf("LatestReviewsIterator reported %d errors, want 1", errs)
+	}
+	if call != 3 {
+		t.Errorf("feed fetched %d times, want 3", call)
+	}
+	if want := []string{"r1", "r2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("LatestReviewsIterator yielded %v, want %v", got, want)
+	}
+}
+
 func bsetup() (client *Client, mux *http.ServeMux, serverURL string, teardown func()) {
 	// mux is the HTTP request multiplexer used with the test server.
 	mux = http.NewServeMux()
@@ -274,3 +520,158 @@ func testQueryParams(t *testing.T, r *http.Request, q, want string) {
 		t.Errorf("Query params is %s, want %s", got, want)
 	}
 }
+
+func TestBusiness_sendServiceReviews(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/reviews/r1/reply", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"message":"Thanks"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+	})
+	got, _, err := client.Business.SendServiceReviews(ctx, "r1", "Thanks")
+	if err != nil {
+		t.Errorf("SendServiceReviews returned error: %v", err)
+	}
+	want := &ServiceReviewResp{Message: String("Thanks")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SendServiceReviews returned %+v, want %+v", got, want)
+	}
+}
+
+func TestBusiness_upsertServiceReviewReply(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/reviews/r1/reply", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"message":"Thanks a lot"}`+"\n")
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("/private/reviews/r1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"r1","companyReply":{"text":"Thanks a lot","createdAt":"2013-09-07T13:37:00Z","updatedAt":"2013-09-08T10:00:00Z"}}`)
+	})
+	got, _, err := client.Business.UpsertServiceReviewReply(ctx, "r1", "Thanks a lot")
+	if err != nil {
+		t.Errorf("UpsertServiceReviewReply returned error: %v", err)
+	}
+	want := &ServiceReviewResp{Message: String("Thanks a lot"), CreatedAt: String("2013-09-07T13:37:00Z"), UpdatedAt: String("2013-09-08T10:00:00Z")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UpsertServiceReviewReply returned %+v, want %+v", got, want)
+	}
+}
+
+func TestBusiness_deleteServiceReviewReply(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/reviews/r1/reply", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	if _, err := client.Business.DeleteServiceReviewReply(ctx, "r1"); err != nil {
+		t.Errorf("DeleteServiceReviewReply returned error: %v", err)
+	}
+}
+
+func TestBusiness_serviceReviewTags(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/reviews/r1/tags", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"tags":[{"group":"team","value":"support"}]}`)
+		case "POST", "DELETE":
+			testBody(t, r, `{"tags":[{"group":"team","value":"billing"}]}`+"\n")
+		case "PUT":
+			testBody(t, r, `{"tags":[]}`+"\n")
+		default:
+			t.Errorf("unexpected method %v", r.Method)
+		}
+	})
+
+	got, _, err := client.Business.ListServiceReviewTags(ctx, "r1")
+	if err != nil {
+		t.Errorf("ListServiceReviewTags returned error: %v", err)
+	}
+	if want := []*Tag{{Group: String("team"), Value: String("support")}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ListServiceReviewTags returned %+v, want %+v", got, want)
+	}
+	billing := []*Tag{{Group: String("team"), Value: String("billing")}}
+	if _, err := client.Business.AddServiceReviewTags(ctx, "r1", billing); err != nil {
+		t.Errorf("AddServiceReviewTags returned error: %v", err)
+	}
+	if _, err := client.Business.RemoveServiceReviewTags(ctx, "r1", billing); err != nil {
+		t.Errorf("RemoveServiceReviewTags returned error: %v", err)
+	}
+	if _, err := client.Business.ReplaceServiceReviewTags(ctx, "r1", nil); err != nil {
+		t.Errorf("ReplaceServiceReviewTags returned error: %v", err)
+	}
+}
+
+func TestBusiness_listBusinessUnitTags(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/business-units/b1/tags", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"tags":[{"group":"team","value":"support"},{"group":"team","value":"billing"}]}`)
+	})
+	got, _, err := client.Business.ListBusinessUnitTags(ctx, "b1")
+	if err != nil {
+		t.Errorf("ListBusinessUnitTags returned error: %v", err)
+	}
+	want := []*Tag{{Group: String("team"), Value: String("support")}, {Group: String("team"), Value: String("billing")}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListBusinessUnitTags returned %+v, want %+v", got, want)
+	}
+}
+
+func TestBusiness_reportServiceReview(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/reviews/r1/report", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testHeader(t, r, "Authorization", "Bearer t0k3n")
+		testBody(t, r, `{"reason":"offensiveOrAbusive","reasonComment":"Threatens our staff"}`+"\n")
+		fmt.Fprint(w, `{"reportId":"rep1"}`)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("t0k3n")})
+	got, _, err := client.Business.ReportServiceReview(ctx, "r1", ReportReasonOffensive, "Threatens our staff")
+	if err != nil {
+		t.Errorf("ReportServiceReview returned error: %v", err)
+	}
+	if want := (&ReviewReport{ReportID: String("rep1")}); !reflect.DeepEqual(got, want) {
+		t.Errorf("ReportServiceReview returned %+v, want %+v", got, want)
+	}
+}
+
+func TestBusiness_findReviewerRequests(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/reviews/r1/find-reviewer", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"message":"Could you share your order number?"}`+"\n")
+		fmt.Fprint(w, `{"id":"f1","status":"requested","message":"Could you share your order number?"}`)
+	})
+	mux.HandleFunc("/private/reviews/r1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"r1","findReviewer":{"isEligible":false,"requests":[{"id":"f1","status":"consumerResponded"}]}}`)
+	})
+
+	got, _, err := client.Business.CreateFindReviewerRequest(ctx, "r1", "Could you share your order number?")
+	if err != nil {
+		t.Errorf("CreateFindReviewerRequest returned error: %v", err)
+	}
+	want := &FindReviewerRequest{ID: String("f1"), Status: String(FindReviewerRequestStatusRequested), Message: String("Could you share your order number?")}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateFindReviewerRequest returned %+v, want %+v", got, want)
+	}
+
+	list, _, err := client.Business.ListFindReviewerRequests(ctx, "r1")
+	if err != nil {
+		t.Errorf("ListFindReviewerRequests returned error: %v", err)
+	}
+	wantList := []*FindReviewerRequest{{ID: String("f1"), Status: String(FindReviewerRequestStatusConsumerResponded)}}
+	if !reflect.DeepEqual(list, wantList) {
+		t.Errorf("ListFindReviewerRequests returned %+v, want %+v", list, wantList)
+	}
+}
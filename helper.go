@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -182,8 +184,8 @@ func (*AcceptedError) Error() string {
 	return "job scheduled on trustpilot side; try again later"
 }
 
-// RateLimitError occurs when GitHub returns 403 Forbidden response with a rate limit
-// remaining value of 0.
+// RateLimitError occurs when Trustpilot returns 429 Too Many Requests, or a 403
+// Forbidden response with a rate limit remaining value of 0.
 type RateLimitError struct {
 	Rate     Rate           // Rate specifies last known rate limit for the client
 	Response *http.Response // HTTP response that caused this error
@@ -191,23 +193,29 @@ type RateLimitError struct {
 }
 
 func (r *RateLimitError) Error() string {
-	return fmt.Sprintf("%v %v: %d %v %v",
+	msg := fmt.Sprintf("%v %v: %d %v",
 		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-		r.Response.StatusCode, r.Message, formatRateReset(time.Until(r.Rate.Reset.Time)))
+		r.Response.StatusCode, r.Message)
+	if r.Rate.Reset.IsZero() {
+		// A 429 without rate limit headers does not say when it resets.
+		return msg
+	}
+	return msg + " " + formatRateReset(time.Until(r.Rate.Reset.Time))
 }
 
-// sanitizeURL redacts the client_secret parameter from the URL which may be
-// exposed to the user.
+// sanitizeURL returns a copy of the URL with the client_secret parameter
+// redacted, as it may be exposed to the user.
 func sanitizeURL(uri *url.URL) *url.URL {
 	if uri == nil {
 		return nil
 	}
-	params := uri.Query()
+	u := *uri
+	params := u.Query()
 	if len(params.Get("client_secret")) > 0 {
 		params.Set("client_secret", "REDACTED")
-		uri.RawQuery = params.Encode()
+		u.RawQuery = params.Encode()
 	}
-	return uri
+	return &u
 }
 
 // formatRateReset formats d to look like "[rate reset in 2s]" or
@@ -238,8 +246,9 @@ func formatRateReset(d time.Duration) string {
 // CheckResponse checks the API response for errors, and returns them if
 // present. A response is considered an error if it has a status code outside
 // the 200 range or equal to 202 Accepted.
-// API error responses are expected to have response
-// body, and a JSON response body that maps to ErrorResponse.
+// API error responses are parsed into ErrorResponse, whether they are JSON
+// error bodies of the API, OAuth errors of the token endpoints, gateway
+// faults or plain text.
 func CheckResponse(r *http.Response) error {
 	if r.StatusCode == http.StatusAccepted {
 		return &AcceptedError{}
@@ -250,11 +259,12 @@ func CheckResponse(r *http.Response) error {
 	errorResponse := &ErrorResponse{Response: r}
 	data, err := ioutil.ReadAll(r.Body)
 	if err == nil && data != nil {
-		json.Unmarshal(data, errorResponse)
+		errorResponse.parseBody(data)
 	}
 	r.Body = ioutil.NopCloser(bytes.NewBuffer(data))
 	switch {
-	case r.StatusCode == http.StatusForbidden && r.Header.Get(headerRateRemaining) == "0":
+	case r.StatusCode == http.StatusTooManyRequests,
+		r.StatusCode == http.StatusForbidden && r.Header.Get(headerRateRemaining) == "0":
 		return &RateLimitError{
 			Rate:     parseRate(r),
 			Response: errorResponse.Response,
@@ -265,25 +275,147 @@ func CheckResponse(r *http.Response) error {
 	}
 }
 
+// ErrorResponse reports an error returned by the API.
 type ErrorResponse struct {
 	Response *http.Response // HTTP response that caused this error
-	Message  string         `json:"message"` // error message
-	Errors   []Error        `json:"errors"`  // more detail on individual errors
-	Block    *struct {
-		Reason    string     `json:"reason,omitempty"`
-		CreatedAt *Timestamp `json:"created_at,omitempty"`
-	} `json:"block,omitempty"`
+	Message  string         // error message
+	Code     string         // error code, e.g. "invalid_grant" or "keymanagement.service.access_token_expired"
+	Details  string         // further details, if given
+	Errors   []Error        // more detail on individual errors
+	Raw      []byte         // raw response body, for debugging
 }
 
+// Error describes a single validation error.
 type Error struct {
 	Resource string `json:"resource"` // resource on which the error occurred
 	Field    string `json:"field"`    // field on which the error occurred
 	Code     string `json:"code"`     // validation error code
-	Message  string `json:"message"`  // Message describing the error. Errors with Code == "custom" will always have this set.
+	Message  string `json:"message"`  // Message describing the error
 }
 
 func (r *ErrorResponse) Error() string {
+	msg := r.Message
+	if r.Code != "" {
+		msg = fmt.Sprintf("%v (%v)", msg, r.Code)
+	}
 	return fmt.Sprintf("%v %v: %d %v %+v",
 		r.Response.Request.Method, sanitizeURL(r.Response.Request.URL),
-		r.Response.StatusCode, r.Message, r.Errors)
+		r.Response.StatusCode, msg, r.Errors)
+}
+
+// parseBody fills r from the error body data. The API answers with
+// {"message", "errorCode", "details"}, the OAuth endpoints with
+// {"error", "error_description"} and the API gateway with
+// {"fault": {"faultstring", "detail": {"errorcode"}}}. Anything else is
+// taken as a plain text message.
+func (r *ErrorResponse) parseBody(data []byte) {
+	r.Raw = data
+	var body struct {
+		Message          string          `json:"message"`
+		ErrorCode        json.RawMessage `json:"errorCode"`
+		Details          json.RawMessage `json:"details"`
+		Errors           []Error         `json:"errors"`
+		OAuthError       json.RawMessage `json:"error"`
+		ErrorDescription string          `json:"error_description"`
+		Fault            *struct {
+			FaultString string `json:"faultstring"`
+			Detail      struct {
+				ErrorCode string `json:"errorcode"`
+			} `json:"detail"`
+		} `json:"fault"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		r.Message = strings.TrimSpace(string(data))
+		return
+	}
+	r.Message = body.Message
+	r.Code = rawString(body.ErrorCode)
+	r.Details = rawString(body.Details)
+	r.Errors = body.Errors
+	if oauthErr := rawString(body.OAuthError); oauthErr != "" {
+		if r.Code == "" {
+			r.Code = oauthErr
+		}
+		if r.Message == "" {
+			r.Message = body.ErrorDescription
+		}
+		if r.Message == "" {
+			r.Message = oauthErr
+		}
+	}
+	if body.Fault != nil {
+		if r.Message == "" {
+			r.Message = body.Fault.FaultString
+		}
+		if r.Code == "" {
+			r.Code = body.Fault.Detail.ErrorCode
+		}
+	}
+}
+
+// rawString returns a JSON string value unquoted, and any other JSON value
+// as is.
+func rawString(raw json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	if string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
+
+// Sentinel errors matching API errors by HTTP status. Errors returned by the
+// client can be tested against them with errors.Is, and unwrapped with
+// errors.As into *ErrorResponse or *RateLimitError for the details:
+//
+//	if errors.Is(err, trustpilot.ErrTokenExpired) {
+//		// refresh the token
+//	}
+var (
+	ErrBadRequest   = errors.New("trustpilot: bad request")         // 400, usually a validation error
+	ErrUnauthorized = errors.New("trustpilot: unauthorized")        // 401, missing, invalid or expired token
+	ErrTokenExpired = errors.New("trustpilot: token expired")       // 401 caused by an expired access token
+	ErrForbidden    = errors.New("trustpilot: forbidden")           // 403
+	ErrNotFound     = errors.New("trustpilot: not found")           // 404, e.g. a deleted review
+	ErrConflict     = errors.New("trustpilot: conflict")            // 409
+	ErrServer       = errors.New("trustpilot: server error")        // 5xx
+	ErrRateLimited  = errors.New("trustpilot: rate limit exceeded") // 429, or 403 with no remaining rate
+)
+
+// Is reports whether r matches target, one of the sentinel errors above.
+func (r *ErrorResponse) Is(target error) bool {
+	if r.Response == nil {
+		return false
+	}
+	switch code := r.Response.StatusCode; target {
+	case ErrBadRequest:
+		return code == http.StatusBadRequest
+	case ErrUnauthorized:
+		return code == http.StatusUnauthorized
+	case ErrTokenExpired:
+		return code == http.StatusUnauthorized && r.tokenExpired()
+	case ErrForbidden:
+		return code == http.StatusForbidden
+	case ErrNotFound:
+		return code == http.StatusNotFound
+	case ErrConflict:
+		return code == http.StatusConflict
+	case ErrServer:
+		return code >= 500 && code <= 599
+	}
+	return false
+}
+
+// tokenExpired reports whether the API rejected the access token because it
+// expired.
+func (r *ErrorResponse) tokenExpired() bool {
+	return strings.Contains(strings.ToLower(r.Code), "expired") ||
+		strings.Contains(strings.ToLower(r.Message), "expired")
+}
+
+// Is reports whether target is ErrRateLimited.
+func (r *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
 }
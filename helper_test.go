@@ -0,0 +1,142 @@
+package trustpilot
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestErrorResponse_Is(t *testing.T) {
+	tests := []struct {
+		status  int
+		body    string
+		matches []error
+	}{
+		{http.StatusBadRequest, `{"message":"Invalid stars","errors":[{"field":"stars","message":"must be 1-5"}]}`, []error{ErrBadRequest}},
+		{http.StatusUnauthorized, `{"message":"Invalid access token"}`, []error{ErrUnauthorized}},
+		{http.StatusUnauthorized, `{"message":"Access Token expired"}`, []error{ErrUnauthorized, ErrTokenExpired}},
+		{http.StatusForbidden, `{"message":"Forbidden"}`, []error{ErrForbidden}},
+		{http.StatusNotFound, `{"message":"Review not found"}`, []error{ErrNotFound}},
+		{http.StatusConflict, `{"message":"Reply already exists"}`, []error{ErrConflict}},
+		{http.StatusBadGateway, ``, []error{ErrServer}},
+		{http.StatusTooManyRequests, `{"message":"Slow down"}`, []error{ErrRateLimited}},
+	}
+	all := []error{ErrBadRequest, ErrUnauthorized, ErrTokenExpired, ErrForbidden, ErrNotFound, ErrConflict, ErrServer, ErrRateLimited}
+
+	for _, tt := range tests {
+		client, mux, _, teardown := bsetup()
+		mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			fmt.Fprint(w, tt.body)
+		})
+		_, _, err := client.Business.GetServiceReviews(ctx, nil)
+		teardown()
+
+		for _, sentinel := range all {
+			want := false
+			for _, m := range tt.matches {
+				want = want || m == sentinel
+			}
+			if got := errors.Is(fmt.Errorf("wrapped: %w", err), sentinel); got != want {
+				t.Errorf("status %d: errors.Is(err, %v) is %v, want %v", tt.status, sentinel, got, want)
+			}
+		}
+	}
+}
+
+func TestErrorResponse_As(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"message":"Invalid","errors":[{"field":"stars","message":"must be 1-5"}]}`)
+	})
+	_, _, err := client.Business.GetServiceReviews(ctx, nil)
+
+	var errResp *ErrorResponse
+	if !errors.As(err, &errResp) {
+		t.Fatalf("errors.As(%v, *ErrorResponse) failed", err)
+	}
+	if len(errResp.Errors) != 1 || errResp.Errors[0].Field != "stars" {
+		t.Errorf("ErrorResponse.Errors is %+v, want the stars error", errResp.Errors)
+	}
+}
+
+func TestCheckResponse_errorBodies(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        ErrorResponse
+	}{
+		{
+			name: "api",
+			body: `{"message":"Review not found","errorCode":1004,"details":"507f191e810c19729de860ea"}`,
+			want: ErrorResponse{Message: "Review not found", Code: "1004", Details: "507f191e810c19729de860ea"},
+		},
+		{
+			name: "oauth",
+			body: `{"error":"invalid_grant","error_description":"Refresh token is invalid"}`,
+			want: ErrorResponse{Message: "Refresh token is invalid", Code: "invalid_grant"},
+		},
+		{
+			name: "gateway fault",
+			body: `{"fault":{"faultstring":"Access Token expired","detail":{"errorcode":"keymanagement.service.access_token_expired"}}}`,
+			want: ErrorResponse{Message: "Access Token expired", Code: "keymanagement.service.access_token_expired"},
+		},
+		{
+			name:        "plain text",
+			contentType: "text/plain",
+			body:        "Service Unavailable\n",
+			want:        ErrorResponse{Message: "Service Unavailable"},
+		},
+	}
+	for _, tt := range tests {
+		res := &http.Response{
+			Request:    &http.Request{Method: "GET"},
+			StatusCode: http.StatusBadRequest,
+			Header:     http.Header{"Content-Type": {tt.contentType}},
+			Body:       ioutil.NopCloser(strings.NewReader(tt.body)),
+		}
+		err, ok := CheckResponse(res).(*ErrorResponse)
+		if !ok {
+			t.Fatalf("%s: CheckResponse returned %#v, want *ErrorResponse", tt.name, err)
+		}
+		if err.Message != tt.want.Message || err.Code != tt.want.Code || err.Details != tt.want.Details {
+			t.Errorf("%s: CheckResponse returned message %q code %q details %q, want %q %q %q",
+				tt.name, err.Message, err.Code, err.Details, tt.want.Message, tt.want.Code, tt.want.Details)
+		}
+		if string(err.Raw) != tt.body {
+			t.Errorf("%s: ErrorResponse.Raw is %q, want %q", tt.name, err.Raw, tt.body)
+		}
+	}
+}
+
+func TestErrorResponse_Is_tokenExpiredCode(t *testing.T) {
+	err := &ErrorResponse{
+		Response: &http.Response{StatusCode: http.StatusUnauthorized},
+		Code:     "keymanagement.service.access_token_expired",
+	}
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("errors.Is(%v, ErrTokenExpired) is false", err.Code)
+	}
+}
+
+func TestRateLimitError_Error_withoutRateHeaders(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"message":"Slow down"}`)
+	})
+	_, _, err := client.Business.GetServiceReviews(ctx, nil)
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Fatalf("GetServiceReviews returned %#v, want *RateLimitError", err)
+	}
+	if msg := err.Error(); !strings.HasSuffix(msg, ": 429 Slow down") {
+		t.Errorf("RateLimitError.Error() is %q, want it to end with the message and no reset time", msg)
+	}
+}
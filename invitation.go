@@ -0,0 +1,177 @@
+package trustpilot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// InvitationService handles communication with the invitation related
+// methods of the Trustpilot API. They are served from InvitationsURL rather
+// than BaseURL.
+//
+// Trustpilot Invitation API docs: https://developers.trustpilot.com/invitation-api
+type InvitationService service
+
+func (i InvitationService) String() string {
+	return Stringify(i)
+}
+
+// EmailInvitation is an invitation emailed to a consumer to review the
+// business and, optionally, the products they bought.
+type EmailInvitation struct {
+	ConsumerEmail   *string `json:"consumerEmail"`
+	ConsumerName    *string `json:"consumerName,omitempty"`
+	ReferenceNumber *string `json:"referenceNumber,omitempty"`
+	Locale          *string `json:"locale,omitempty"`
+	SenderEmail     *string `json:"senderEmail,omitempty"`
+	SenderName      *string `json:"senderName,omitempty"`
+	ReplyTo         *string `json:"replyTo,omitempty"`
+	LocationID      *string `json:"locationId,omitempty"`
+
+	// At least one of ServiceReviewInvitation and ProductReviewInvitation
+	// must be set.
+	ServiceReviewInvitation *ServiceReviewInvitation `json:"serviceReviewInvitation,omitempty"`
+	ProductReviewInvitation *ProductReviewInvitation `json:"productReviewInvitation,omitempty"`
+}
+
+func (e EmailInvitation) String() string {
+	return Stringify(e)
+}
+
+// ServiceReviewInvitation asks the consumer to review the business.
+type ServiceReviewInvitation struct {
+	TemplateID *string `json:"templateId,omitempty"`
+
+	// PreferredSendTime delays the invitation, e.g. until the order is
+	// delivered. It is sent as is, so it should be in UTC.
+	PreferredSendTime *time.Time `json:"preferredSendTime,omitempty"`
+
+	RedirectURI *string  `json:"redirectUri,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+// ProductReviewInvitation asks the consumer to review the products they
+// bought.
+type ProductReviewInvitation struct {
+	TemplateID        *string              `json:"templateId,omitempty"`
+	PreferredSendTime *time.Time           `json:"preferredSendTime,omitempty"`
+	RedirectURI       *string              `json:"redirectUri,omitempty"`
+	Products          []*InvitationProduct `json:"products"`
+}
+
+// InvitationProduct is a product the consumer is invited to review.
+type InvitationProduct struct {
+	ProductURL *string `json:"productUrl"`
+	ImageURL   *string `json:"imageUrl,omitempty"`
+	Name       *string `json:"name"`
+	SKU        *string `json:"sku,omitempty"`
+	GTIN       *string `json:"gtin,omitempty"`
+	MPN        *string `json:"mpn,omitempty"`
+	Brand      *string `json:"brand,omitempty"`
+}
+
+// ValidationError reports the fields of a request that were found invalid
+// before sending it. It matches ErrBadRequest, like the error the API returns
+// for an invalid request.
+type ValidationError struct {
+	Errors []Error
+}
+
+func (v *ValidationError) Error() string {
+	msgs := make([]string, len(v.Errors))
+	for i, e := range v.Errors {
+		msgs[i] = e.Field + " " + e.Message
+	}
+	return "trustpilot: invalid request: " + strings.Join(msgs, ", ")
+}
+
+// Is reports whether target is ErrBadRequest.
+func (v *ValidationError) Is(target error) bool {
+	return target == ErrBadRequest
+}
+
+// Validate checks that the invitation can be sent, returning a
+// *ValidationError listing every invalid field otherwise. A nil invitation
+// is invalid.
+func (e *EmailInvitation) Validate() error {
+	v := &ValidationError{}
+	invalid := func(field, code, msg string) {
+		v.Errors = append(v.Errors, Error{Resource: "EmailInvitation", Field: field, Code: code, Message: msg})
+	}
+	if e == nil {
+		invalid("invitation", "missing_field", "is required")
+		return v
+	}
+	checkEmail := func(field string, addr *string, required bool) {
+		switch {
+		case addr == nil || *addr == "":
+			if required {
+				invalid(field, "missing_field", "is required")
+			}
+		default:
+			if _, err := mail.ParseAddress(*addr); err != nil {
+				invalid(field, "invalid", "is not a valid email address")
+			}
+		}
+	}
+
+	checkEmail("consumerEmail", e.ConsumerEmail, true)
+	checkEmail("senderEmail", e.SenderEmail, false)
+	checkEmail("replyTo", e.ReplyTo, false)
+	if e.ServiceReviewInvitation == nil && e.ProductReviewInvitation == nil {
+		invalid("serviceReviewInvitation", "missing_field", "or productReviewInvitation is required")
+	}
+	if p := e.ProductReviewInvitation; p != nil {
+		if len(p.Products) == 0 {
+			invalid("productReviewInvitation.products", "missing_field", "is required")
+		}
+		for n, prod := range p.Products {
+			field := fmt.Sprintf("productReviewInvitation.products[%d]", n)
+			if prod == nil {
+				invalid(field, "missing_field", "is required")
+				continue
+			}
+			if StringValue(prod.Name) == "" {
+				invalid(field+".name", "missing_field", "is required")
+			}
+			if StringValue(prod.ProductURL) == "" {
+				invalid(field+".productUrl", "missing_field", "is required")
+			}
+		}
+	}
+
+	if len(v.Errors) > 0 {
+		return v
+	}
+	return nil
+}
+
+// CreateInvitation Create an email invitation
+// This method validates the invitation and asks Trustpilot to email it to the consumer. The API queues invitations,
+// so a nil error means the invitation was accepted, not that it was already sent.
+//
+// https://developers.trustpilot.com/invitation-api#create-new-invitation
+func (i *InvitationService) CreateInvitation(ctx context.Context, businessUnitID string, invitation *EmailInvitation) (*Response, error) {
+	if err := invitation.Validate(); err != nil {
+		return nil, err
+	}
+	u, err := i.client.InvitationsURL.Parse(fmt.Sprintf("private/business-units/%s/email-invitations", businessUnitID))
+	if err != nil {
+		return nil, err
+	}
+	req, err := i.client.NewRequest("POST", u.String(), invitation)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := i.client.Do(ctx, req, nil)
+	var accepted *AcceptedError
+	if errors.As(err, &accepted) {
+		// 202 Accepted is how the API acknowledges a queued invitation.
+		return resp, nil
+	}
+	return resp, err
+}
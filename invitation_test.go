@@ -0,0 +1,86 @@
+package trustpilot
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInvitation_createInvitation(t *testing.T) {
+	client, mux, serverURL, teardown := bsetup()
+	defer teardown()
+	if _, err := client.WithEndpoints(Endpoints{InvitationsURL: serverURL + testBBaseURLPath}); err != nil {
+		t.Fatalf("WithEndpoints returned error: %v", err)
+	}
+	mux.HandleFunc("/private/business-units/b1/email-invitations", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testHeader(t, r, "Authorization", "Bearer t0k3n")
+		testBody(t, r, `{"consumerEmail":"jane@example.com","consumerName":"Jane","referenceNumber":"order-1","locale":"en-US",`+
+			`"replyTo":"support@example.com","serviceReviewInvitation":{"templateId":"t1","preferredSendTime":"2013-09-07T13:37:00Z","tags":["web"]},`+
+			`"productReviewInvitation":{"products":[{"productUrl":"https://example.com/p/1","name":"Shoe","sku":"ABC-1234"}]}}`+"\n")
+		w.WriteHeader(http.StatusAccepted)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("t0k3n")})
+
+	sendAt := time.Date(2013, 9, 7, 13, 37, 0, 0, time.UTC)
+	_, err := client.Invitations.CreateInvitation(ctx, "b1", &EmailInvitation{
+		ConsumerEmail:   String("jane@example.com"),
+		ConsumerName:    String("Jane"),
+		ReferenceNumber: String("order-1"),
+		Locale:          String("en-US"),
+		ReplyTo:         String("support@example.com"),
+		ServiceReviewInvitation: &ServiceReviewInvitation{
+			TemplateID:        String("t1"),
+			PreferredSendTime: &sendAt,
+			Tags:              []string{"web"},
+		},
+		ProductReviewInvitation: &ProductReviewInvitation{
+			Products: []*InvitationProduct{{ProductURL: String("https://example.com/p/1"), Name: String("Shoe"), SKU: String("ABC-1234")}},
+		},
+	})
+	if err != nil {
+		t.Errorf("CreateInvitation returned error: %v", err)
+	}
+}
+
+func TestInvitation_createInvitation_invalid(t *testing.T) {
+	client, mux, serverURL, teardown := bsetup()
+	defer teardown()
+	if _, err := client.WithEndpoints(Endpoints{InvitationsURL: serverURL + testBBaseURLPath}); err != nil {
+		t.Fatalf("WithEndpoints returned error: %v", err)
+	}
+	mux.HandleFunc("/private/business-units/b1/email-invitations", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("an invalid invitation was sent")
+	})
+
+	_, err := client.Invitations.CreateInvitation(ctx, "b1", &EmailInvitation{
+		ConsumerEmail: String("jane"),
+		ProductReviewInvitation: &ProductReviewInvitation{
+			Products: []*InvitationProduct{{Name: String("Shoe")}},
+		},
+	})
+	if !errors.Is(err, ErrBadRequest) {
+		t.Errorf("CreateInvitation returned %v, want an error matching ErrBadRequest", err)
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("CreateInvitation returned %T, want *ValidationError", err)
+	}
+	var fields []string
+	for _, e := range verr.Errors {
+		fields = append(fields, e.Field)
+	}
+	if want := []string{"consumerEmail", "productReviewInvitation.products[0].productUrl"}; !reflect.DeepEqual(fields, want) {
+		t.Errorf("ValidationError fields are %v, want %v", fields, want)
+	}
+}
+
+func TestInvitation_createInvitation_nil(t *testing.T) {
+	client := NewClient(nil)
+	var verr *ValidationError
+	if _, err := client.Invitations.CreateInvitation(ctx, "b1", nil); !errors.As(err, &verr) {
+		t.Errorf("CreateInvitation returned %v, want a *ValidationError", err)
+	}
+}
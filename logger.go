@@ -0,0 +1,81 @@
+package trustpilot
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Logger receives structured log events from a Client. keyvals holds
+// alternating keys and values, e.g. "status", 200.
+//
+// A Client logs nothing unless its Logger is set.
+type Logger interface {
+	Debug(msg string, keyvals ...interface{})
+	Error(msg string, keyvals ...interface{})
+}
+
+// NewStdLogger returns a Logger writing to l in logfmt style. Debug events
+// are dropped unless debug is set.
+func NewStdLogger(l *log.Logger, debug bool) Logger {
+	return &stdLogger{l: l, debug: debug}
+}
+
+type stdLogger struct {
+	l     *log.Logger
+	debug bool
+}
+
+func (s *stdLogger) Debug(msg string, keyvals ...interface{}) {
+	if s.debug {
+		s.output("DEBUG", msg, keyvals)
+	}
+}
+
+func (s *stdLogger) Error(msg string, keyvals ...interface{}) {
+	s.output("ERROR", msg, keyvals)
+}
+
+func (s *stdLogger) output(level, msg string, keyvals []interface{}) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "level=%s msg=%q", level, msg)
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", keyvals[i], keyvals[i+1])
+	}
+	s.l.Output(3, b.String())
+}
+
+// logRequest logs req before it is sent.
+func (c *Client) logRequest(req *http.Request) {
+	if c.Logger == nil {
+		return
+	}
+	c.Logger.Debug("trustpilot: request",
+		"method", req.Method,
+		"url", sanitizeURL(req.URL))
+}
+
+// logResponse logs the outcome of sending req, started at start.
+func (c *Client) logResponse(req *http.Request, resp *http.Response, start time.Time, err error) {
+	if c.Logger == nil {
+		return
+	}
+	if err != nil {
+		c.Logger.Error("trustpilot: request failed",
+			"method", req.Method,
+			"url", sanitizeURL(req.URL),
+			"latency", time.Since(start),
+			"error", err)
+		return
+	}
+	c.Logger.Debug("trustpilot: response",
+		"method", req.Method,
+		"url", sanitizeURL(req.URL),
+		"status", resp.StatusCode,
+		"latency", time.Since(start),
+		"rate_limit", resp.Header.Get(headerRateLimit),
+		"rate_remaining", resp.Header.Get(headerRateRemaining),
+		"rate_reset", resp.Header.Get(headerRateReset))
+}
@@ -0,0 +1,87 @@
+package trustpilot
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type logEntry struct {
+	level   string
+	msg     string
+	keyvals map[string]interface{}
+}
+
+type recordingLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (l *recordingLogger) Debug(msg string, keyvals ...interface{}) { l.record("debug", msg, keyvals) }
+func (l *recordingLogger) Error(msg string, keyvals ...interface{}) { l.record("error", msg, keyvals) }
+
+func (l *recordingLogger) record(level, msg string, keyvals []interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	e := logEntry{level: level, msg: msg, keyvals: map[string]interface{}{}}
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		e.keyvals[fmt.Sprint(keyvals[i])] = keyvals[i+1]
+	}
+	l.entries = append(l.entries, e)
+}
+
+func TestClient_Logger(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateRemaining, "41")
+		fmt.Fprint(w, `{"reviews":[]}`)
+	})
+	logger := new(recordingLogger)
+	client.Logger = logger
+
+	req, _ := client.NewRequest("GET", "reviews/latest?client_secret=s3cret", nil)
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if len(logger.entries) != 2 {
+		t.Fatalf("logged %d entries, want 2", len(logger.entries))
+	}
+	resp := logger.entries[1]
+	if resp.level != "debug" || resp.keyvals["status"] != http.StatusOK || resp.keyvals["rate_remaining"] != "41" {
+		t.Errorf("response log entry is %+v", resp)
+	}
+	if u := fmt.Sprint(resp.keyvals["url"]); strings.Contains(u, "s3cret") {
+		t.Errorf("logged URL %v contains the client secret", u)
+	}
+	if got := req.URL.Query().Get("client_secret"); got != "s3cret" {
+		t.Errorf("logging modified the request URL, client_secret is %q", got)
+	}
+}
+
+func TestNewStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	l := NewStdLogger(log.New(&buf, "", 0), false)
+	l.Debug("dropped", "a", 1)
+	l.Error("kept", "status", 500)
+	if got, want := buf.String(), "level=ERROR msg=\"kept\" status=500\n"; got != want {
+		t.Errorf("NewStdLogger wrote %q, want %q", got, want)
+	}
+}
+
+func TestSanitizeURL(t *testing.T) {
+	u, _ := url.Parse("https://api.trustpilot.com/v1/x?client_secret=s3cret&a=b")
+	got := sanitizeURL(u)
+	if got.Query().Get("client_secret") != "REDACTED" {
+		t.Errorf("sanitizeURL returned %v, want client_secret redacted", got)
+	}
+	if u.Query().Get("client_secret") != "s3cret" {
+		t.Errorf("sanitizeURL modified its argument to %v", u)
+	}
+}
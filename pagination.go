@@ -0,0 +1,163 @@
+package trustpilot
+
+import (
+	"context"
+	"net/http"
+)
+
+// relNextPage is the relation of the link to the next page of a list.
+const relNextPage = "next-page"
+
+// NextPageURL returns the URL of the next page given the links of a list
+// response, or "" if it is the last page.
+func NextPageURL(links []*Links) string {
+	for _, l := range links {
+		if l != nil && StringValue(l.REL) == relNextPage {
+			return StringValue(l.HREF)
+		}
+	}
+	return ""
+}
+
+// page is a single page of a list response.
+type page interface {
+	len() int
+	nextPage() string
+}
+
+// Iterator walks every page of a list endpoint, following the next-page
+// links of the responses. It is embedded in the typed iterators, such as
+// ServiceReviewIterator, which give access to the current item.
+//
+// Pages are fetched as needed by Next, which stops at the last page, on the
+// first error or when ctx is done:
+//
+//	it := client.Business.BusinessUnitReviews(businessUnitID, nil)
+//	for it.Next(ctx) {
+//		process(it.Review())
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator struct {
+	client  *Client
+	next    string              // URL of the next page, "" after the last one
+	newPage func() page         // allocates the value a page is decoded into
+	prepare func(*http.Request) // sets up the request of each page, e.g. its authentication
+	page    page
+	i       int
+	resp    *Response
+	err     error
+}
+
+func newIterator(client *Client, first string, newPage func() page, prepare func(*http.Request)) Iterator {
+	return Iterator{client: client, next: first, newPage: newPage, prepare: prepare}
+}
+
+// Next advances to the next item, fetching the next page if needed. It
+// returns false when all pages have been walked or an error occurred.
+func (it *Iterator) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+	it.i++
+	for it.page == nil || it.i >= it.page.len() {
+		if it.next == "" {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		if !it.fetch(ctx) {
+			return false
+		}
+		it.i = 0
+	}
+	return true
+}
+
+func (it *Iterator) fetch(ctx context.Context) bool {
+	current := it.next
+	req, err := it.client.NewRequest("GET", current, nil)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	if it.prepare != nil {
+		it.prepare(req)
+	}
+	p := it.newPage()
+	resp, err := it.client.Do(ctx, req, p)
+	it.resp = resp
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = p
+	it.next = p.nextPage()
+	if it.next == current {
+		// Never loop on a page linking to itself.
+		it.next = ""
+	}
+	return true
+}
+
+// current returns the page holding the current item, or nil if there is no
+// current item, i.e. before the first call to Next or after it returned
+// false.
+func (it *Iterator) current() page {
+	if it.page == nil || it.i < 0 || it.i >= it.page.len() {
+		return nil
+	}
+	return it.page
+}
+
+// Response returns the response of the last fetched page.
+func (it *Iterator) Response() *Response { return it.resp }
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator) Err() error { return it.err }
+
+// ServiceReviewIterator walks every page of a service review list.
+type ServiceReviewIterator struct {
+	Iterator
+}
+
+// Review returns the current review, or nil if there is none.
+func (it *ServiceReviewIterator) Review() *SingleServiceReview {
+	p, ok := it.current().(*ServiceReviews)
+	if !ok {
+		return nil
+	}
+	return p.Reviews[it.i]
+}
+
+// PrivateServiceReviewIterator walks every page of a private service review
+// list.
+type PrivateServiceReviewIterator struct {
+	Iterator
+}
+
+// Review returns the current review, or nil if there is none.
+func (it *PrivateServiceReviewIterator) Review() *PrivateServiceReview {
+	p, ok := it.current().(*PrivateServiceReviews)
+	if !ok {
+		return nil
+	}
+	return p.Reviews[it.i]
+}
+
+// ProductReviewIterator walks every page of a product review list.
+type ProductReviewIterator struct {
+	Iterator
+}
+
+// Review returns the current review, or nil if there is none.
+func (it *ProductReviewIterator) Review() *SingleProductReview {
+	p, ok := it.current().(*ProductReviews)
+	if !ok {
+		return nil
+	}
+	return p.Reviews[it.i]
+}
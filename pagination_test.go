@@ -0,0 +1,137 @@
+package trustpilot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestNextPageURL(t *testing.T) {
+	links := []*Links{
+		{HREF: String("https://api.trustpilot.com/v1/x?page=1"), REL: String("prev-page")},
+		{HREF: String("https://api.trustpilot.com/v1/x?page=3"), REL: String("next-page")},
+	}
+	if got, want := NextPageURL(links), "https://api.trustpilot.com/v1/x?page=3"; got != want {
+		t.Errorf("NextPageURL returned %v, want %v", got, want)
+	}
+	if got := NextPageURL(links[:1]); got != "" {
+		t.Errorf("NextPageURL on the last page returned %v, want empty", got)
+	}
+}
+
+func TestBusiness_businessUnitReviews_walksAllPages(t *testing.T) {
+	client, mux, serverURL, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/business-units/b1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Authorization", "xxxxxxx")
+		switch page := r.URL.Query().Get("page"); page {
+		case "":
+			testFormValues(t, r, values{"perPage": "2"})
+			fmt.Fprintf(w, `{"reviews":[{"id":"r1"},{"id":"r2"}],"links":[{"href":"%s/v1/business-units/b1/reviews?page=2&perPage=2","method":"GET","rel":"next-page"}]}`, serverURL)
+		case "2":
+			// An empty page in the middle must not end the iteration.
+			fmt.Fprintf(w, `{"reviews":[],"links":[{"href":"%s/v1/business-units/b1/reviews?page=3&perPage=2","rel":"next-page"}]}`, serverURL)
+		case "3":
+			fmt.Fprint(w, `{"reviews":[{"id":"r3"}],"links":[{"href":"x","rel":"prev-page"}]}`)
+		default:
+			t.Errorf("unexpected page %v", page)
+		}
+	})
+	client.ClientID = "xxxxxxx"
+
+	it := client.Business.BusinessUnitReviews("b1", &BusinessUnitReviewsOptions{ListOptions: ListOptions{PerPage: 2}})
+	var got []string
+	for it.Next(ctx) {
+		got = append(got, StringValue(it.Review().ID))
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("ServiceReviewIterator returned error: %v", err)
+	}
+	if want := []string{"r1", "r2", "r3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("ServiceReviewIterator yielded %v, want %v", got, want)
+	}
+	if it.Next(ctx) {
+		t.Errorf("Next returned true after the last page")
+	}
+}
+
+func TestIterator_stopsOnCanceledContext(t *testing.T) {
+	client, mux, serverURL, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/product-reviews/business-units/b1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"productReviews":[{"id":"p1"}],"links":[{"href":"%s/v1/private/product-reviews/business-units/b1/reviews?page=2","rel":"next-page"}]}`, serverURL)
+	})
+
+	cctx, cancel := context.WithCancel(ctx)
+	it := client.Product.PrivateReviews("b1", nil)
+	if !it.Next(cctx) || StringValue(it.Review().ID) != "p1" {
+		t.Fatalf("first Next did not yield p1, err %v", it.Err())
+	}
+	cancel()
+	if it.Next(cctx) {
+		t.Errorf("Next returned true after the context was canceled")
+	}
+	if it.Err() != context.Canceled {
+		t.Errorf("Err returned %v, want %v", it.Err(), context.Canceled)
+	}
+}
+
+func TestIterator_stopsOnSelfLink(t *testing.T) {
+	client, mux, serverURL, teardown := bsetup()
+	defer teardown()
+	var calls int
+	mux.HandleFunc("/product-reviews/business-units/b1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{"productReviews":[{"id":"p1"}],"links":[{"href":"%s/v1/product-reviews/business-units/b1/reviews?sku=s1","rel":"next-page"}]}`, serverURL)
+	})
+
+	it := client.Product.Reviews("b1", &ProductReviewsOptions{SKU: []string{"s1"}})
+	it.next = serverURL + "/v1/product-reviews/business-units/b1/reviews?sku=s1"
+	n := 0
+	for it.Next(ctx) {
+		n++
+	}
+	if n != 1 || calls != 1 {
+		t.Errorf("iterator yielded %d reviews in %d calls, want 1 in 1", n, calls)
+	}
+}
+
+func TestIterator_reviewWithoutCurrentItem(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/business-units/b1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"reviews":[{"id":"r1"}]}`)
+	})
+	mux.HandleFunc("/private/business-units/b1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"reviews":[{"id":"r1"}]}`)
+	})
+	mux.HandleFunc("/product-reviews/business-units/b1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"productReviews":[{"id":"p1"}]}`)
+	})
+
+	type iterator struct {
+		next   func() bool
+		review func() interface{}
+	}
+	sit := client.Business.BusinessUnitReviews("b1", nil)
+	pit := client.Business.PrivateBusinessUnitReviews("b1", nil)
+	prit := client.Product.Reviews("b1", nil)
+	its := []iterator{
+		{func() bool { return sit.Next(ctx) }, func() interface{} { return sit.Review() }},
+		{func() bool { return pit.Next(ctx) }, func() interface{} { return pit.Review() }},
+		{func() bool { return prit.Next(ctx) }, func() interface{} { return prit.Review() }},
+	}
+
+	for n, it := range its {
+		if r := it.review(); !reflect.ValueOf(r).IsNil() {
+			t.Errorf("iterator %d: Review before Next returned %v, want nil", n, r)
+		}
+		for it.next() {
+		}
+		if r := it.review(); !reflect.ValueOf(r).IsNil() {
+			t.Errorf("iterator %d: Review after the last item returned %v, want nil", n, r)
+		}
+	}
+}
@@ -1,9 +1,9 @@
 package trustpilot
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
-	"log"
+	"net/http"
 )
 
 // ProductService handles communication with the product review related
@@ -22,6 +22,27 @@ func (p ProductService) String() string {
 //ProductReviews ...
 type ProductReviews struct {
 	Reviews []*SingleProductReview `json:"productReviews"`
+	Links   []*Links               `json:"links"`
+}
+
+func (pr *ProductReviews) len() int         { return len(pr.Reviews) }
+func (pr *ProductReviews) nextPage() string { return NextPageURL(pr.Links) }
+
+// ProductReviewsOptions specifies the optional parameters to the
+// ProductService methods listing product reviews. At least one SKU or
+// product URL must be given.
+type ProductReviewsOptions struct {
+	SKU        []string `url:"sku,omitempty,comma"`
+	ProductURL []string `url:"productUrl,omitempty,comma"`
+
+	// Language only returns reviews in these languages, e.g. "en".
+	Language []string `url:"language,omitempty,comma"`
+
+	// State only returns reviews in these states, e.g. "published" or
+	// "unpublished". It only applies to private reviews.
+	State []string `url:"state,omitempty,comma"`
+
+	ListOptions
 }
 
 //SingleProductReview ...
@@ -33,6 +54,10 @@ type SingleProductReview struct {
 	Consumer    *BusinessUnit `json:"consumer"`
 	Links       []*Links
 	Attachments []*Attachments
+
+	// ConversationID is the ID of the conversation about the review, if
+	// one was created with CreateConversation.
+	ConversationID *string `json:"conversationId"`
 }
 
 //Attachments product review attachment
@@ -56,30 +81,24 @@ type TFiles struct {
 //Pagination and filtering reviews by language is also possible.
 //
 //https://developers.trustpilot.com/product-reviews-api#get-product-reviews
-func (p *ProductService) GetProductReviews(businessUnitID string) (*ProductReviews, error) {
-	u := fmt.Sprintf("%s/product-reviews/business-units/%s/reviews", fakeURL, businessUnitID)
-	if isTEST {
-		u = "/product-reviews/business-units/507f191e810c19729de860ea/reviews"
+func (p *ProductService) GetProductReviews(ctx context.Context, businessUnitID string, opts *ProductReviewsOptions) (*ProductReviews, *Response, error) {
+	u := fmt.Sprintf("product-reviews/business-units/%s/reviews", businessUnitID)
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
 	}
 	pr := new(ProductReviews)
 	req, err := p.client.NewRequest("GET", u, nil)
 	if err != nil {
-		log.Printf("Err %v", err)
-		return pr, err
+		return nil, nil, err
 	}
 
 	req.Header.Add("Authorization", ""+p.client.ClientID)
-	resp, err := p.client.Do(p.client.CTX, req)
-	if err != nil {
-		log.Printf("Err1 %v", err)
-		return pr, err
-	}
-	err = json.Unmarshal(resp, &pr)
-
+	resp, err := p.client.Do(ctx, req, pr)
 	if err != nil {
-		return pr, err
+		return nil, resp, err
 	}
-	return pr, nil
+	return pr, resp, nil
 }
 
 //GetProductPrivateReviews Get private product review
@@ -87,28 +106,164 @@ func (p *ProductService) GetProductReviews(businessUnitID string) (*ProductRevie
 //By default only published reviews are returned. To get reviews with other states, provide a list in the state field. Pagination is used to retrieve all results.
 //
 //https://developers.trustpilot.com/product-reviews-api#get-product-reviews
-func (p *ProductService) GetProductPrivateReviews(token, businessUnitID string) (*ProductReviews, error) {
-	u := fmt.Sprintf("%s/private/product-reviews/business-units/%s/reviews", fakeURL, businessUnitID)
-	if isTEST {
-		u = "/private/product-reviews/business-units/507f191e810c19729de860ea/reviews"
+func (p *ProductService) GetProductPrivateReviews(ctx context.Context, businessUnitID string, opts *ProductReviewsOptions) (*ProductReviews, *Response, error) {
+	u := fmt.Sprintf("private/product-reviews/business-units/%s/reviews", businessUnitID)
+	u, err := addOptions(u, opts)
+	if err != nil {
+		return nil, nil, err
 	}
 	pr := new(ProductReviews)
 	req, err := p.client.NewRequest("GET", u, nil)
 	if err != nil {
-		log.Printf("Err %v", err)
-		return pr, err
+		return nil, nil, err
+	}
+	resp, err := p.client.Do(ctx, req, pr)
+	if err != nil {
+		return nil, resp, err
+	}
+	return pr, resp, nil
+}
+
+// Reviews returns an iterator over the product reviews of a business unit,
+// walking all pages of GetProductReviews from the page given in opts.
+func (p *ProductService) Reviews(businessUnitID string, opts *ProductReviewsOptions) *ProductReviewIterator {
+	u := fmt.Sprintf("product-reviews/business-units/%s/reviews", businessUnitID)
+	u, err := addOptions(u, opts)
+	it := &ProductReviewIterator{newIterator(p.client, u, func() page { return new(ProductReviews) }, func(req *http.Request) {
+		req.Header.Add("Authorization", ""+p.client.ClientID)
+	})}
+	it.err = err
+	return it
+}
+
+// PrivateReviews returns an iterator over the private product reviews of a
+// business unit, walking all pages of GetProductPrivateReviews from the page
+// given in opts.
+func (p *ProductService) PrivateReviews(businessUnitID string, opts *ProductReviewsOptions) *ProductReviewIterator {
+	u := fmt.Sprintf("private/product-reviews/business-units/%s/reviews", businessUnitID)
+	u, err := addOptions(u, opts)
+	it := &ProductReviewIterator{newIterator(p.client, u, func() page { return new(ProductReviews) }, nil)}
+	it.err = err
+	return it
+}
+
+// Conversation states accepted by ProductService.SetConversationState.
+const (
+	ConversationStateOpen   = "open"
+	ConversationStateClosed = "closed"
+)
+
+//Conversation is a thread of comments about a product review
+type Conversation struct {
+	ID        *string                `json:"id"`
+	State     *string                `json:"state"`
+	CreatedAt *string                `json:"createdAt"`
+	Comments  []*ConversationComment `json:"comments"`
+}
+
+//ConversationComment is a single comment in a conversation
+type ConversationComment struct {
+	ID        *string                  `json:"id"`
+	Content   *string                  `json:"content"`
+	Author    *ConversationParticipant `json:"author"`
+	CreatedAt *string                  `json:"createdAt"`
+	UpdatedAt *string                  `json:"updatedAt"`
+}
+
+//ConversationParticipant is the author of a comment, either a business user or the consumer
+type ConversationParticipant struct {
+	ID          *string `json:"id"`
+	DisplayName *string `json:"displayName"`
+	Type        *string `json:"type"`
+}
+
+//CreateConversation Create a conversation for a product review
+//This method creates a conversation about a product review, in which the business can reply to the consumer.
+//Only the ID of the new conversation is set in the returned Conversation.
+//
+//https://developers.trustpilot.com/product-reviews-api#create-conversation-for-product-review
+func (p *ProductService) CreateConversation(ctx context.Context, reviewID string) (*Conversation, *Response, error) {
+	u := fmt.Sprintf("private/product-reviews/%s/create-conversation", reviewID)
+	req, err := p.client.NewRequest("POST", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	created := new(struct {
+		ConversationID *string `json:"conversationId"`
+	})
+	resp, err := p.client.Do(ctx, req, created)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &Conversation{ID: created.ConversationID}, resp, nil
+}
+
+//GetConversation Get a conversation
+//This method gets a conversation, including all of its comments.
+//
+//https://developers.trustpilot.com/conversations-api#get-conversation
+func (p *ProductService) GetConversation(ctx context.Context, conversationID string) (*Conversation, *Response, error) {
+	u := fmt.Sprintf("private/conversations/%s", conversationID)
+	c := new(Conversation)
+	req, err := p.client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := p.client.Do(ctx, req, c)
+	if err != nil {
+		return nil, resp, err
 	}
+	return c, resp, nil
+}
 
-	req.Header.Add("Authorization", ""+token)
-	resp, err := p.client.Do(p.client.CTX, req)
+//ListConversationComments List the comments of a conversation
+//This method gets the comments of a conversation, oldest first.
+//
+//https://developers.trustpilot.com/conversations-api#get-conversation
+func (p *ProductService) ListConversationComments(ctx context.Context, conversationID string) ([]*ConversationComment, *Response, error) {
+	c, resp, err := p.GetConversation(ctx, conversationID)
 	if err != nil {
-		log.Printf("Err1 %v", err)
-		return pr, err
+		return nil, resp, err
 	}
-	err = json.Unmarshal(resp, &pr)
+	return c.Comments, resp, nil
+}
+
+//CommentOnConversation Reply in a conversation
+//This method posts a comment to a conversation on behalf of the business user with the given ID.
+//
+//https://developers.trustpilot.com/conversations-api#comment-on-a-conversation
+func (p *ProductService) CommentOnConversation(ctx context.Context, conversationID, businessUserID, content string) (*ConversationComment, *Response, error) {
+	u := fmt.Sprintf("private/conversations/%s/comments", conversationID)
+	c := new(ConversationComment)
+	reqBody := &struct {
+		Content string `json:"content"`
+	}{Content: content}
+
+	req, err := p.client.NewRequest("POST", u, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("x-business-user-id", businessUserID)
+	resp, err := p.client.Do(ctx, req, c)
+	if err != nil {
+		return nil, resp, err
+	}
+	return c, resp, nil
+}
+
+//SetConversationState Change the state of a conversation
+//This method changes the state of a conversation to one of the ConversationState constants.
+//
+//https://developers.trustpilot.com/conversations-api#change-conversation-state
+func (p *ProductService) SetConversationState(ctx context.Context, conversationID, state string) (*Response, error) {
+	u := fmt.Sprintf("private/conversations/%s/state", conversationID)
+	reqBody := &struct {
+		State string `json:"state"`
+	}{State: state}
 
+	req, err := p.client.NewRequest("POST", u, reqBody)
 	if err != nil {
-		return pr, err
+		return nil, err
 	}
-	return pr, nil
+	return p.client.Do(ctx, req, nil)
 }
@@ -0,0 +1,120 @@
+package trustpilot
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestProduct_getProductReviews(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/product-reviews/business-units/507f191e810c19729de860ea/reviews", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "xxxxxxx")
+		testFormValues(t, r, values{"sku": "ABC-1234,ABC-5678", "language": "en", "page": "2"})
+		fmt.Fprint(w, `{"productReviews":[{"id":"p1","content":"Great","stars":5}],"links":[]}`)
+	})
+	client.ClientID = "xxxxxxx"
+	got, _, err := client.Product.GetProductReviews(ctx, "507f191e810c19729de860ea", &ProductReviewsOptions{
+		SKU:         []string{"ABC-1234", "ABC-5678"},
+		Language:    []string{"en"},
+		ListOptions: ListOptions{Page: 2},
+	})
+	if err != nil {
+		t.Errorf("GetProductReviews returned error: %v", err)
+	}
+	want := &ProductReviews{
+		Reviews: []*SingleProductReview{{ID: String("p1"), Content: String("Great"), Stars: Int(5)}},
+		Links:   []*Links{},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetProductReviews returned %+v, want %+v", got, want)
+	}
+}
+
+func TestProduct_getProductPrivateReviews(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/product-reviews/business-units/507f191e810c19729de860ea/reviews", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testHeader(t, r, "Authorization", "Bearer t0k3n")
+		testFormValues(t, r, values{"sku": "ABC-1234", "state": "published,unpublished"})
+		fmt.Fprint(w, `{"productReviews":[{"id":"p1"}]}`)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("t0k3n")})
+	got, _, err := client.Product.GetProductPrivateReviews(ctx, "507f191e810c19729de860ea", &ProductReviewsOptions{
+		SKU:   []string{"ABC-1234"},
+		State: []string{"published", "unpublished"},
+	})
+	if err != nil {
+		t.Errorf("GetProductPrivateReviews returned error: %v", err)
+	}
+	if len(got.Reviews) != 1 || StringValue(got.Reviews[0].ID) != "p1" {
+		t.Errorf("GetProductPrivateReviews returned %+v", got)
+	}
+}
+
+func TestProduct_createConversation(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/product-reviews/p1/create-conversation", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		fmt.Fprint(w, `{"conversationId":"c1"}`)
+	})
+	got, _, err := client.Product.CreateConversation(ctx, "p1")
+	if err != nil {
+		t.Errorf("CreateConversation returned error: %v", err)
+	}
+	if want := (&Conversation{ID: String("c1")}); !reflect.DeepEqual(got, want) {
+		t.Errorf("CreateConversation returned %+v, want %+v", got, want)
+	}
+}
+
+func TestProduct_listConversationComments(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/conversations/c1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, `{"id":"c1","state":"open","comments":[{"id":"m1","content":"Does it fit?","author":{"id":"u1","type":"consumer"}}]}`)
+	})
+	got, _, err := client.Product.ListConversationComments(ctx, "c1")
+	if err != nil {
+		t.Errorf("ListConversationComments returned error: %v", err)
+	}
+	want := []*ConversationComment{{ID: String("m1"), Content: String("Does it fit?"), Author: &ConversationParticipant{ID: String("u1"), Type: String("consumer")}}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ListConversationComments returned %+v, want %+v", got, want)
+	}
+}
+
+func TestProduct_commentOnConversation(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/conversations/c1/comments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testHeader(t, r, "x-business-user-id", "bu1")
+		testBody(t, r, `{"content":"It runs large"}`+"\n")
+		fmt.Fprint(w, `{"id":"m2","content":"It runs large"}`)
+	})
+	got, _, err := client.Product.CommentOnConversation(ctx, "c1", "bu1", "It runs large")
+	if err != nil {
+		t.Errorf("CommentOnConversation returned error: %v", err)
+	}
+	if want := (&ConversationComment{ID: String("m2"), Content: String("It runs large")}); !reflect.DeepEqual(got, want) {
+		t.Errorf("CommentOnConversation returned %+v, want %+v", got, want)
+	}
+}
+
+func TestProduct_setConversationState(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/private/conversations/c1/state", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testBody(t, r, `{"state":"closed"}`+"\n")
+	})
+	if _, err := client.Product.SetConversationState(ctx, "c1", ConversationStateClosed); err != nil {
+		t.Errorf("SetConversationState returned error: %v", err)
+	}
+}
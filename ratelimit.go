@@ -0,0 +1,158 @@
+package trustpilot
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimitCategory groups the endpoints that share a rate limit.
+type RateLimitCategory int
+
+const (
+	// PublicCategory covers the public API, called with the API key.
+	PublicCategory RateLimitCategory = iota
+	// PrivateCategory covers the private API, called with an access token.
+	PrivateCategory
+	// OAuthCategory covers the OAuth token endpoints.
+	OAuthCategory
+	// InvitationsCategory covers the invitations API, which has its own host
+	// and rate limit.
+	InvitationsCategory
+
+	categories // An array of this length will be able to contain all rate limit categories.
+)
+
+// category returns the rate limit category of req.
+func (c *Client) category(req *http.Request) RateLimitCategory {
+	for _, u := range []string{c.AccessTokenURL.String(), c.RefreshURL.String(), c.RevokeURL.String()} {
+		if strings.HasPrefix(req.URL.String(), u) {
+			return OAuthCategory
+		}
+	}
+	if strings.HasPrefix(req.URL.String(), c.InvitationsURL.String()) {
+		return InvitationsCategory
+	}
+	if strings.Contains(req.URL.Path, "/private/") {
+		return PrivateCategory
+	}
+	return PublicCategory
+}
+
+// Rate returns the last rate limit the API reported for cat.
+func (c *Client) Rate(cat RateLimitCategory) Rate {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rateLimits[cat]
+}
+
+// SetLimiter paces requests in cat through l. A nil l removes the limiter.
+func (c *Client) SetLimiter(cat RateLimitCategory, l Limiter) {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	c.limiters[cat] = l
+}
+
+func (c *Client) limiter(cat RateLimitCategory) Limiter {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.limiters[cat]
+}
+
+// updateRate records the rate limit reported by resp, if any.
+func (c *Client) updateRate(cat RateLimitCategory, resp *http.Response) {
+	if resp.Header.Get(headerRateRemaining) == "" {
+		return
+	}
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	c.rateLimits[cat] = parseRate(resp)
+}
+
+// checkRateLimitBeforeDo does not make any network calls, but uses existing
+// knowledge from the current client state in order to quickly check if
+// *RateLimitError can be immediately returned from Client.Do, and if so,
+// returns it so that Client.Do can skip making a network API call
+// unnecessarily.
+func (c *Client) checkRateLimitBeforeDo(req *http.Request, cat RateLimitCategory) *RateLimitError {
+	rate := c.Rate(cat)
+	if rate.Remaining > 0 || rate.Reset.IsZero() || !time.Now().Before(rate.Reset.Time) {
+		return nil
+	}
+	// Create a fake response.
+	resp := &http.Response{
+		Status:     http.StatusText(http.StatusTooManyRequests),
+		StatusCode: http.StatusTooManyRequests,
+		Request:    req,
+		Header:     make(http.Header),
+		Body:       ioutil.NopCloser(strings.NewReader("")),
+	}
+	return &RateLimitError{
+		Rate:     rate,
+		Response: resp,
+		Message:  fmt.Sprintf("API rate limit of %v still exceeded until %v, not making remote request.", rate.Limit, rate.Reset.Time),
+	}
+}
+
+// Limiter paces requests on the client side, before they reach the API's
+// own rate limit.
+type Limiter interface {
+	// Wait blocks until a request may be made or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// TokenBucket is a Limiter allowing rate requests per second on average,
+// with bursts of up to burst requests.
+type TokenBucket struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex // mu guards tokens and last.
+	tokens float64
+	last   time.Time
+}
+
+// NewTokenBucket returns a full TokenBucket. A rate of 0 or less means no
+// limit, and a burst below 1 is raised to 1.
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// Wait takes a token from the bucket, blocking until one is available or ctx
+// is done.
+func (tb *TokenBucket) Wait(ctx context.Context) error {
+	if tb.rate <= 0 {
+		return ctx.Err()
+	}
+	for {
+		tb.mu.Lock()
+		now := time.Now()
+		tb.tokens += now.Sub(tb.last).Seconds() * tb.rate
+		if tb.tokens > tb.burst {
+			tb.tokens = tb.burst
+		}
+		tb.last = now
+		if tb.tokens >= 1 {
+			tb.tokens--
+			tb.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+		tb.mu.Unlock()
+
+		t := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package trustpilot
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDo_rateLimitExceededBeforeCall(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	var calls int32
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set(headerRateLimit, "60")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set(headerRateReset, strconv.FormatInt(reset.Unix(), 10))
+		fmt.Fprint(w, `{"reviews":[]}`)
+	})
+	mux.HandleFunc("/private/reviews/r1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"r1"}`)
+	})
+
+	if _, _, err := client.Business.GetServiceReviews(ctx, nil); err != nil {
+		t.Fatalf("first call returned error: %v", err)
+	}
+	_, resp, err := client.Business.GetServiceReviews(ctx, nil)
+	rateErr, ok := err.(*RateLimitError)
+	if !ok {
+		t.Fatalf("second call returned error %#v, want *RateLimitError", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("server called %d times, want 1", got)
+	}
+	if !rateErr.Rate.Reset.Time.Equal(reset) {
+		t.Errorf("RateLimitError reset is %v, want %v", rateErr.Rate.Reset, reset)
+	}
+	if resp == nil || resp.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("second call returned response %v, want status %d", resp, http.StatusTooManyRequests)
+	}
+	if got := client.Rate(PublicCategory).Limit; got != 60 {
+		t.Errorf("Rate(PublicCategory).Limit is %d, want 60", got)
+	}
+
+	// Private endpoints are limited separately.
+	if _, _, err := client.Business.GetServicePrivateReview(ctx, "r1"); err != nil {
+		t.Errorf("private call returned error: %v", err)
+	}
+}
+
+func TestCheckResponse_tooManyRequests(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"message":"Too many requests"}`)
+	})
+	if _, _, err := client.Business.GetServiceReviews(ctx, nil); err == nil {
+		t.Fatal("GetServiceReviews returned no error")
+	} else if _, ok := err.(*RateLimitError); !ok {
+		t.Errorf("GetServiceReviews returned error %#v, want *RateLimitError", err)
+	}
+}
+
+func TestClient_SetLimiter(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"reviews":[]}`)
+	})
+	client.SetLimiter(PublicCategory, NewTokenBucket(20, 1))
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, _, err := client.Business.GetServiceReviews(ctx, nil); err != nil {
+			t.Fatalf("GetServiceReviews returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("3 calls at 20/s with burst 1 took %v, want at least 100ms", elapsed)
+	}
+}
+
+func TestTokenBucket_Wait_canceled(t *testing.T) {
+	tb := NewTokenBucket(0.001, 1)
+	if err := tb.Wait(context.Background()); err != nil {
+		t.Fatalf("Wait on a full bucket returned error: %v", err)
+	}
+	cctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := tb.Wait(cctx); err != context.DeadlineExceeded {
+		t.Errorf("Wait on an empty bucket returned %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestTokenBucket_Wait_noRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		tb := NewTokenBucket(rate, 0)
+		cctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			if err := tb.Wait(cctx); err != nil {
+				t.Errorf("Wait with rate %v returned error: %v", rate, err)
+			}
+		}
+		cancel()
+		if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+			t.Errorf("3 calls with rate %v took %v, want no waiting", rate, elapsed)
+		}
+	}
+}
+
+func TestDo_invitationsRateLimitedSeparately(t *testing.T) {
+	client, mux, serverURL, teardown := bsetup()
+	defer teardown()
+	if _, err := client.WithEndpoints(Endpoints{InvitationsURL: serverURL + testBBaseURLPath + "/invitations/"}); err != nil {
+		t.Fatalf("WithEndpoints returned error: %v", err)
+	}
+	mux.HandleFunc("/invitations/private/business-units/b1/email-invitations", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(headerRateLimit, "100")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set(headerRateReset, strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusAccepted)
+	})
+	var calls int32
+	mux.HandleFunc("/private/reviews/r1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		fmt.Fprint(w, `{"id":"r1"}`)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("t0k3n")})
+
+	invitation := &EmailInvitation{ConsumerEmail: String("jane@example.com"), ServiceReviewInvitation: &ServiceReviewInvitation{}}
+	if _, err := client.Invitations.CreateInvitation(ctx, "b1", invitation); err != nil {
+		t.Fatalf("CreateInvitation returned error: %v", err)
+	}
+	if rate := client.Rate(InvitationsCategory); rate.Remaining != 0 || rate.Limit != 100 {
+		t.Errorf("Rate(InvitationsCategory) is %+v, want limit 100 with 0 remaining", rate)
+	}
+	if _, _, err := client.Business.GetServicePrivateReview(ctx, "r1"); err != nil {
+		t.Errorf("private call returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("private endpoint called %d times, want 1", got)
+	}
+}
@@ -0,0 +1,166 @@
+package trustpilot
+
+import (
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy configures how Client.Do retries requests that failed with a
+// transient error: a connection reset or timeout, 429 Too Many Requests or
+// a 5xx server error. Only idempotent requests are retried unless
+// RetryNonIdempotent is set.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. It doubles with every
+	// further retry, up to MaxBackoff. A zero MaxBackoff means no cap.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+
+	// Jitter shortens each backoff by a random fraction of up to Jitter, so
+	// that clients failing together do not retry together. It is between 0
+	// and 1.
+	Jitter float64
+
+	// RetryNonIdempotent allows retrying POST and PATCH requests, such as
+	// SendServiceReviews, which the API may then apply twice.
+	RetryNonIdempotent bool
+
+	// MaxWait caps the wait the API asks for, through Retry-After or the
+	// reset time of an exhausted rate limit. If the API asks to wait longer,
+	// the request is not retried and its error, usually a *RateLimitError,
+	// is returned. Zero means MaxBackoff, and no cap if that is zero too.
+	MaxWait time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy suitable for most batch jobs.
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  30 * time.Second,
+		Jitter:      0.5,
+	}
+}
+
+// shouldRetry reports whether the attempt-th attempt at req, which ended
+// with resp and err, should be retried. A nil policy never retries.
+func (p *RetryPolicy) shouldRetry(req *http.Request, attempt int, resp *Response, err error) bool {
+	if p == nil || err == nil || attempt >= p.MaxAttempts {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+	if d, ok := serverDelay(resp, err); ok {
+		if max := p.maxWait(); max > 0 && d > max {
+			return false
+		}
+	}
+	if resp != nil {
+		switch resp.StatusCode {
+		case http.StatusTooManyRequests,
+			http.StatusInternalServerError,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+		_, ok := err.(*RateLimitError)
+		return ok
+	}
+	return isTransient(err)
+}
+
+// delay returns how long to wait before retrying after the attempt-th
+// attempt. Retry-After and an exhausted rate limit take precedence over the
+// exponential backoff.
+func (p *RetryPolicy) delay(attempt int, resp *Response, err error) time.Duration {
+	if d, ok := serverDelay(resp, err); ok {
+		return d
+	}
+	shift := uint(attempt - 1)
+	d := p.MinBackoff << shift
+	if d>>shift != p.MinBackoff {
+		d = math.MaxInt64 // overflow
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(rand.Float64() * p.Jitter * float64(d))
+	}
+	return d
+}
+
+// maxWait returns the longest wait the API may ask for before a retry, or 0
+// for no limit.
+func (p *RetryPolicy) maxWait() time.Duration {
+	if p.MaxWait > 0 {
+		return p.MaxWait
+	}
+	return p.MaxBackoff
+}
+
+// serverDelay returns the wait the API asked for after a failed attempt,
+// from Retry-After or the reset time of an exhausted rate limit.
+func serverDelay(resp *Response, err error) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	if d, ok := retryAfter(resp.Header); ok {
+		return d, true
+	}
+	if rerr, ok := err.(*RateLimitError); ok && !rerr.Rate.Reset.IsZero() {
+		if d := time.Until(rerr.Rate.Reset.Time); d > 0 {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as
+// an HTTP date.
+func retryAfter(h http.Header) (time.Duration, bool) {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case "GET", "HEAD", "OPTIONS", "PUT", "DELETE":
+		return true
+	}
+	return false
+}
+
+// isTransient reports whether err, returned without any response, is worth
+// retrying.
+func isTransient(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+		return true
+	}
+	var nerr net.Error
+	return errors.As(err, &nerr) && nerr.Timeout()
+}
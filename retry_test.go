@@ -0,0 +1,175 @@
+package trustpilot
+
+import (
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+}
+
+func TestDo_retriesTransientFailures(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	var calls int32
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"reviews":[]}`)
+	})
+	client.RetryPolicy = testRetryPolicy()
+	if _, _, err := client.Business.GetServiceReviews(ctx, nil); err != nil {
+		t.Errorf("GetServiceReviews returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("server called %d times, want 3", got)
+	}
+}
+
+func TestDo_givesUpAfterMaxAttempts(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	var calls int32
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	})
+	client.RetryPolicy = testRetryPolicy()
+	_, resp, err := client.Business.GetServiceReviews(ctx, nil)
+	if err == nil {
+		t.Errorf("GetServiceReviews returned no error")
+	}
+	if resp == nil || resp.StatusCode != http.StatusBadGateway {
+		t.Errorf("GetServiceReviews returned response %v, want status %d", resp, http.StatusBadGateway)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("server called %d times, want 3", got)
+	}
+}
+
+func TestDo_retriesNonIdempotentOnlyWhenOptedIn(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	var calls int32
+	mux.HandleFunc("/private/reviews/r1/reply", func(w http.ResponseWriter, r *http.Request) {
+		testBody(t, r, `{"message":"Thanks"}`+"\n")
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	client.RetryPolicy = testRetryPolicy()
+	if _, _, err := client.Business.SendServiceReviews(ctx, "r1", "Thanks"); err == nil {
+		t.Errorf("SendServiceReviews returned no error without RetryNonIdempotent")
+	}
+
+	client.RetryPolicy.RetryNonIdempotent = true
+	atomic.StoreInt32(&calls, 0)
+	if _, _, err := client.Business.SendServiceReviews(ctx, "r1", "Thanks"); err != nil {
+		t.Errorf("SendServiceReviews returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("server called %d times, want 2", got)
+	}
+}
+
+func TestDo_doesNotRetryClientErrors(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	var calls int32
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+	client.RetryPolicy = testRetryPolicy()
+	client.Business.GetServiceReviews(ctx, nil)
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("server called %d times, want 1", got)
+	}
+}
+
+func TestRetryPolicy_delay(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second, 80: 5 * time.Second} {
+		if got := p.delay(attempt, nil, nil); got != want {
+			t.Errorf("delay(%d) is %v, want %v", attempt, got, want)
+		}
+	}
+
+	resp := &Response{Response: &http.Response{Header: http.Header{"Retry-After": {"7"}}}}
+	if got, want := p.delay(1, resp, nil), 7*time.Second; got != want {
+		t.Errorf("delay with Retry-After is %v, want %v", got, want)
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.delay(1, nil, nil); got < 500*time.Millisecond || got > time.Second {
+			t.Fatalf("delay with jitter is %v, want between 500ms and 1s", got)
+		}
+	}
+}
+
+func TestDo_givesUpWhenRateLimitResetIsBeyondMaxWait(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	var calls int32
+	mux.HandleFunc("/business-units/b1", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set(headerRateLimit, "100")
+		w.Header().Set(headerRateRemaining, "0")
+		w.Header().Set(headerRateReset, fmt.Sprint(time.Now().Add(time.Hour).Unix()))
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Second}
+
+	start := time.Now()
+	_, _, err := client.Business.GetBusinessUnit(ctx, "b1")
+	if _, ok := err.(*RateLimitError); !ok {
+		t.Errorf("GetBusinessUnit returned %v, want a *RateLimitError", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("endpoint called %d times, want 1", got)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("GetBusinessUnit took %v, want it to give up without waiting", elapsed)
+	}
+}
+
+func TestRetryPolicy_delay_noMaxBackoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second}
+	if got, want := p.delay(4, nil, nil), 8*time.Second; got != want {
+		t.Errorf("delay(4) without MaxBackoff is %v, want %v", got, want)
+	}
+	if got := p.delay(80, nil, nil); got < time.Hour {
+		t.Errorf("delay(80) without MaxBackoff is %v, want it not to overflow", got)
+	}
+}
+
+func TestDo_retriesRetryAfterWithoutMaxBackoff(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	var calls int32
+	mux.HandleFunc("/business-units/b1", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"id":"b1"}`)
+	})
+	client.RetryPolicy = &RetryPolicy{MaxAttempts: 3}
+
+	if _, _, err := client.Business.GetBusinessUnit(ctx, "b1"); err != nil {
+		t.Errorf("GetBusinessUnit returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("endpoint called %d times, want 2", got)
+	}
+}
@@ -0,0 +1,101 @@
+package trustpilot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// tokenExpiryDelta is how long before its expiry an access token is
+// refreshed, so that it does not expire while a request is in flight.
+const tokenExpiryDelta = time.Minute
+
+// TokenStore persists authorizations refreshed by a TokenSource, e.g. in a
+// database, so that they survive a restart.
+type TokenStore interface {
+	SaveToken(auth *Authorization) error
+}
+
+// TokenSource holds the current Authorization of a Client and refreshes it
+// shortly before it expires. When set on Client.TokenSource, Client.Do adds
+// the access token to every request that has no Authorization header of its
+// own, and refreshes the token once if the API answers 401 Unauthorized and
+// there is a refresh token. If the refresh fails, the 401 is returned as an
+// *ErrorResponse wrapped with the refresh error.
+//
+// A TokenSource is safe for concurrent use.
+type TokenSource struct {
+	// Store, if non-nil, is handed every refreshed Authorization.
+	Store TokenStore
+
+	auths *AuthorizationsService
+
+	mu   sync.Mutex // mu guards auth.
+	auth *Authorization
+}
+
+// NewTokenSource returns a TokenSource starting from auth that refreshes
+// through client.
+func NewTokenSource(client *Client, auth *Authorization) *TokenSource {
+	return &TokenSource{auths: client.Authorizations, auth: auth}
+}
+
+// Token returns the current Authorization, refreshing it first if it is
+// about to expire.
+func (ts *TokenSource) Token(ctx context.Context) (*Authorization, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.auth == nil {
+		return nil, errors.New("trustpilot: token source has no authorization")
+	}
+	if ts.auth.Expired(time.Now().Add(tokenExpiryDelta)) {
+		if err := ts.refreshLocked(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return ts.auth, nil
+}
+
+// refresh refreshes the Authorization unless it has already been replaced
+// since the access token stale was handed out.
+func (ts *TokenSource) refresh(ctx context.Context, stale string) (*Authorization, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	if ts.auth == nil {
+		return nil, errors.New("trustpilot: token source has no authorization")
+	}
+	if StringValue(ts.auth.AccessToken) == stale {
+		if err := ts.refreshLocked(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return ts.auth, nil
+}
+
+func (ts *TokenSource) refreshLocked(ctx context.Context) error {
+	if ts.auth.RefreshToken == nil {
+		return errors.New("trustpilot: access token expired and there is no refresh token")
+	}
+	auth, _, err := ts.auths.RefreshAccessToken(ctx, *ts.auth.RefreshToken)
+	if err != nil {
+		return err
+	}
+	if auth.RefreshToken == nil {
+		auth.RefreshToken = ts.auth.RefreshToken
+	}
+	ts.auth = auth
+	if ts.Store != nil {
+		if err := ts.Store.SaveToken(auth); err != nil {
+			return fmt.Errorf("trustpilot: saving refreshed token: %w", err)
+		}
+	}
+	return nil
+}
+
+// setAuthHeader sets the bearer token of auth on req.
+func setAuthHeader(req *http.Request, auth *Authorization) {
+	req.Header.Set("Authorization", "Bearer "+StringValue(auth.AccessToken))
+}
@@ -0,0 +1,259 @@
+package trustpilot
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type memoryStore struct {
+	mu    sync.Mutex
+	saved []*Authorization
+	err   error
+}
+
+func (m *memoryStore) SaveToken(auth *Authorization) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.saved = append(m.saved, auth)
+	return m.err
+}
+
+func TestTokenSource_refreshesBeforeExpiry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	var refreshes int32
+	mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&refreshes, 1)
+		testFormValues(t, r, values{"grant_type": "refresh_token", "refresh_token": "deadmeat"})
+		fmt.Fprint(w, `{"access_token":"fresh","expires_in":"3600"}`)
+	})
+	mux.HandleFunc("/private/reviews/r1", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, "Authorization", "Bearer fresh")
+		fmt.Fprint(w, `{"id":"r1"}`)
+	})
+	store := new(memoryStore)
+	client.TokenSource = NewTokenSource(client, &Authorization{
+		AccessToken:  String("stale"),
+		RefreshToken: String("deadmeat"),
+		Expiry:       &Timestamp{time.Now().Add(30 * time.Second)},
+	})
+	client.TokenSource.Store = store
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := client.Business.GetServicePrivateReview(ctx, "r1"); err != nil {
+				t.Errorf("GetServicePrivateReview returned error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&refreshes); got != 1 {
+		t.Errorf("token refreshed %d times, want 1", got)
+	}
+	if len(store.saved) != 1 {
+		t.Fatalf("store saved %d tokens, want 1", len(store.saved))
+	}
+	if got := StringValue(store.saved[0].RefreshToken); got != "deadmeat" {
+		t.Errorf("saved refresh token is %q, want the previous one kept", got)
+	}
+}
+
+func TestTokenSource_refreshesOnUnauthorized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"fresh","refresh_token":"beefcafe"}`)
+	})
+	var calls int32
+	mux.HandleFunc("/private/reviews/r1/reply", func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		testBody(t, r, `{"message":"Thanks"}`+"\n")
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{}`)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{
+		AccessToken:  String("revoked"),
+		RefreshToken: String("deadmeat"),
+	})
+	if _, _, err := client.Business.SendServiceReviews(ctx, "r1", "Thanks"); err != nil {
+		t.Fatalf("SendServiceReviews returned error: %v", err)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("reply endpoint called %d times, want 2", got)
+	}
+	auth, _ := client.TokenSource.Token(ctx)
+	if got := StringValue(auth.RefreshToken); got != "beefcafe" {
+		t.Errorf("refresh token is %q, want %q", got, "beefcafe")
+	}
+}
+
+func TestTokenSource_storeError(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"fresh"}`)
+	})
+	ts := NewTokenSource(client, &Authorization{
+		AccessToken:  String("stale"),
+		RefreshToken: String("deadmeat"),
+		Expiry:       &Timestamp{time.Now()},
+	})
+	storeErr := errors.New("disk full")
+	ts.Store = &memoryStore{err: storeErr}
+	if _, err := ts.Token(ctx); !errors.Is(err, storeErr) {
+		t.Errorf("Token returned error %v, want %v", err, storeErr)
+	}
+}
+
+func TestTokenSource_noRefreshToken(t *testing.T) {
+	client := NewClient(nil)
+	ts := NewTokenSource(client, &Authorization{
+		AccessToken: String("stale"),
+		Expiry:      &Timestamp{time.Now()},
+	})
+	if _, err := ts.Token(ctx); err == nil {
+		t.Errorf("Token returned no error for an expired token without refresh token")
+	}
+}
+
+func TestTokenSource_unauthorizedWithoutRefreshToken(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("refresh attempted without a refresh token")
+	})
+	mux.HandleFunc("/private/reviews/r1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Forbidden","errorCode":"insufficient_permissions"}`)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("app")})
+
+	_, _, err := client.Business.GetServicePrivateReview(ctx, "r1")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetServicePrivateReview returned %v, want an error matching ErrUnauthorized", err)
+	}
+	if e, ok := err.(*ErrorResponse); !ok || e.Code != "insufficient_permissions" {
+		t.Errorf("GetServicePrivateReview returned %#v, want the API's *ErrorResponse", err)
+	}
+}
+
+func TestTokenSource_unauthorizedRefreshFails(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"error":"invalid_grant"}`)
+	})
+	mux.HandleFunc("/private/reviews/r1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"message":"Unauthorized","errorCode":"invalid_token"}`)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{
+		AccessToken:  String("revoked"),
+		RefreshToken: String("deadmeat"),
+	})
+
+	_, _, err := client.Business.GetServicePrivateReview(ctx, "r1")
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("GetServicePrivateReview returned %v, want an error matching ErrUnauthorized", err)
+	}
+	var e *ErrorResponse
+	if !errors.As(err, &e) || e.Code != "invalid_token" {
+		t.Errorf("GetServicePrivateReview returned %v, want it to wrap the API's *ErrorResponse", err)
+	}
+}
+
+// jsonStore saves tokens the way most applications would, as JSON.
+type jsonStore struct {
+	data []byte
+}
+
+func (s *jsonStore) SaveToken(auth *Authorization) error {
+	var err error
+	s.data, err = json.Marshal(auth)
+	return err
+}
+
+func TestTokenSource_persistedTokenKeepsExpiry(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	var refreshes int32
+	mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&refreshes, 1)
+		fmt.Fprintf(w, `{"access_token":"fresh%d","refresh_token":"deadmeat","expires_in":"30"}`, n)
+	})
+	store := new(jsonStore)
+	ts := NewTokenSource(client, &Authorization{
+		AccessToken:  String("stale"),
+		RefreshToken: String("deadmeat"),
+		Expiry:       &Timestamp{time.Now()},
+	})
+	ts.Store = store
+	if _, err := ts.Token(ctx); err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+
+	reloaded := new(Authorization)
+	if err := json.Unmarshal(store.data, reloaded); err != nil {
+		t.Fatalf("json.Unmarshal returned error: %v", err)
+	}
+	if reloaded.Expiry == nil {
+		t.Fatalf("reloaded token has no expiry; saved %s", store.data)
+	}
+
+	// The reloaded token expires within tokenExpiryDelta, so a TokenSource
+	// built from it must refresh it again.
+	auth, err := NewTokenSource(client, reloaded).Token(ctx)
+	if err != nil {
+		t.Fatalf("Token returned error: %v", err)
+	}
+	if got, want := StringValue(auth.AccessToken), "fresh2"; got != want {
+		t.Errorf("access token is %q, want %q", got, want)
+	}
+}
+
+func TestDo_leavesCallerRequestUnauthorized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	mux.HandleFunc("/refresh", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"access_token":"fresh"}`)
+	})
+	mux.HandleFunc("/private/reviews/r1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"id":"r1"}`)
+	})
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("first"), RefreshToken: String("deadmeat")})
+
+	req, err := client.NewRequest("GET", "private/reviews/r1", nil)
+	if err != nil {
+		t.Fatalf("NewRequest returned error: %v", err)
+	}
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Fatalf("first Do returned error: %v", err)
+	}
+	if got := req.Header.Get("Authorization"); got != "" {
+		t.Errorf("Do set Authorization %q on the caller's request", got)
+	}
+
+	// The token is revoked; sending the same request again must refresh it.
+	client.TokenSource = NewTokenSource(client, &Authorization{AccessToken: String("revoked"), RefreshToken: String("deadmeat")})
+	if _, err := client.Do(ctx, req, nil); err != nil {
+		t.Errorf("second Do returned error: %v", err)
+	}
+}
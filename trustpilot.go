@@ -7,36 +7,47 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
 	"sync"
+	"time"
 )
 
-var (
-	baseURL        = "https://api.trustpilot.com/v1/" // api domain
-	authURL        = "https://authenticate.trustpilot.com"
-	accessTokenURL = "https://api.trustpilot.com/v1/oauth/oauth-business-users-for-applications/accesstoken"
-	refreshURL     = "https://api.trustpilot.com/v1/oauth/oauth-business-users-for-applications/refresh"
-	revokeURL      = "https://api.trustpilot.com/v1/oauth/oauth-business-users-for-applications/revoke"
-
-	//Due to unavailability of the api access, I used a fake server which behaving same as like trustpilot API server
-	fakeURL = "http://localhost:8005/trustpilot/"
-
-	isTEST = false
+const (
+	defaultBaseURL        = "https://api.trustpilot.com/v1/" // api domain
+	defaultAuthURL        = "https://authenticate.trustpilot.com"
+	defaultAccessTokenURL = "https://api.trustpilot.com/v1/oauth/oauth-business-users-for-applications/accesstoken"
+	defaultRefreshURL     = "https://api.trustpilot.com/v1/oauth/oauth-business-users-for-applications/refresh"
+	defaultRevokeURL      = "https://api.trustpilot.com/v1/oauth/oauth-business-users-for-applications/revoke"
+	defaultInvitationsURL = "https://invitations-api.trustpilot.com/v1/"
 )
 
 // Client manages communication with the trustpilot API.
 type Client struct {
-	CTX      context.Context
 	clientMu sync.Mutex // clientMu protects the client during calls that modify the CheckRedirect func.
 	// HTTP client used to communicate with the API.
 	client *http.Client
 
-	// Base URL for API requests.
+	// Base URL for API requests. BaseURL should always be specified with a
+	// trailing slash.
 	BaseURL *url.URL
 
+	// AuthURL is the Trustpilot page business users are sent to in order to
+	// authorize the application.
+	AuthURL *url.URL
+
+	// AccessTokenURL, RefreshURL and RevokeURL are the OAuth endpoints used to
+	// issue, refresh and revoke access tokens.
+	AccessTokenURL *url.URL
+	RefreshURL     *url.URL
+	RevokeURL      *url.URL
+
+	// InvitationsURL is the base URL of the invitations API, which is served
+	// from its own domain. Like BaseURL, it must have a trailing slash.
+	InvitationsURL *url.URL
+
 	// UserAgent agent used when communicating with Trustpilot API.
 	UserAgent string
 
@@ -49,38 +60,112 @@ type Client struct {
 	// ResponseType is type of response from trustpilot e.g., code, password, implicit
 	ResponseType string
 
+	rateMu     sync.Mutex          // rateMu guards rateLimits and limiters.
+	rateLimits [categories]Rate    // Rate limits for the client as determined by the most recent API calls.
+	limiters   [categories]Limiter // Client side limiters per category, see SetLimiter.
+
+	// Logger, if non-nil, receives debug logs of every request and response.
+	Logger Logger
+
+	// RetryPolicy, if non-nil, makes Do retry transient failures.
+	RetryPolicy *RetryPolicy
+
+	// TokenSource, if non-nil, authorizes requests to the private API.
+	TokenSource *TokenSource
+
 	common service // Reuse a single struct instead of allocating one for each service on the heap.
 
 	// Services used for talking to different parts of the API.
 	Authorizations *AuthorizationsService
 	Business       *BusinessService
 	Product        *ProductService
-
-	// Temporary Response
-	Response *Response
+	Invitations    *InvitationService
 }
 
 type service struct {
 	client *Client
 }
 
-// NewClient returns a new GitHub API client. If a nil httpClient is
-// provided, a new http.Client will be used. To use API methods which require
-// authentication, provide an http.Client that will perform the authentication
-// for you (such as that provided by the golang.org/x/oauth2 library).
+// Endpoints holds the URLs a Client talks to. Empty fields leave the
+// corresponding Client URL unchanged.
+type Endpoints struct {
+	BaseURL        string
+	AuthURL        string
+	AccessTokenURL string
+	RefreshURL     string
+	RevokeURL      string
+	InvitationsURL string
+}
+
+// NewClient returns a new Trustpilot API client pointed at the production
+// endpoints. If a nil httpClient is provided, a new http.Client will be used.
+// Use WithEndpoints to point the client somewhere else.
 func NewClient(httpClient *http.Client) *Client {
 	if httpClient == nil {
 		httpClient = &http.Client{}
 	}
-	bURL, _ := url.Parse(baseURL)
-	c := &Client{client: httpClient, BaseURL: bURL}
+	bURL, _ := url.Parse(defaultBaseURL)
+	aURL, _ := url.Parse(defaultAuthURL)
+	atURL, _ := url.Parse(defaultAccessTokenURL)
+	rfURL, _ := url.Parse(defaultRefreshURL)
+	rvURL, _ := url.Parse(defaultRevokeURL)
+	iURL, _ := url.Parse(defaultInvitationsURL)
+	c := &Client{
+		client:         httpClient,
+		BaseURL:        bURL,
+		AuthURL:        aURL,
+		AccessTokenURL: atURL,
+		RefreshURL:     rfURL,
+		RevokeURL:      rvURL,
+		InvitationsURL: iURL,
+	}
 	c.common.client = c
 	c.Authorizations = (*AuthorizationsService)(&c.common)
 	c.Business = (*BusinessService)(&c.common)
 	c.Product = (*ProductService)(&c.common)
+	c.Invitations = (*InvitationService)(&c.common)
 	return c
 }
 
+// WithEndpoints overrides the URLs used by c with the non-empty fields of e
+// and returns c. A trailing slash is added to BaseURL and InvitationsURL if
+// it is missing.
+func (c *Client) WithEndpoints(e Endpoints) (*Client, error) {
+	endpoints := []struct {
+		raw  string
+		dst  **url.URL
+		base bool // whether relative URLs are resolved against it
+	}{
+		{e.BaseURL, &c.BaseURL, true},
+		{e.AuthURL, &c.AuthURL, false},
+		{e.AccessTokenURL, &c.AccessTokenURL, false},
+		{e.RefreshURL, &c.RefreshURL, false},
+		{e.RevokeURL, &c.RevokeURL, false},
+		{e.InvitationsURL, &c.InvitationsURL, true},
+	}
+	// Parse every URL before changing any, so c is left as it was on error.
+	parsed := make([]*url.URL, len(endpoints))
+	for i, ep := range endpoints {
+		if ep.raw == "" {
+			continue
+		}
+		if ep.base && !strings.HasSuffix(ep.raw, "/") {
+			ep.raw += "/"
+		}
+		u, err := url.Parse(ep.raw)
+		if err != nil {
+			return nil, err
+		}
+		parsed[i] = u
+	}
+	for i, u := range parsed {
+		if u != nil {
+			*endpoints[i].dst = u
+		}
+	}
+	return c, nil
+}
+
 //Response represents the raw http response and rate limit
 type Response struct {
 	*http.Response
@@ -125,6 +210,132 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	return req, nil
 }
 
+// NewFormRequest creates an API request like NewRequest, except that form is
+// sent as an application/x-www-form-urlencoded body, as required by the OAuth
+// endpoints.
+func (c *Client) NewFormRequest(method, urlStr string, form url.Values) (*http.Request, error) {
+	if !strings.HasSuffix(c.BaseURL.Path, "/") {
+		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.BaseURL)
+	}
+	u, err := c.BaseURL.Parse(urlStr)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, u.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, nil
+}
+
+// ListOptions specifies the optional parameters to methods that support
+// pagination.
+type ListOptions struct {
+	// For paginated result sets, page of results to retrieve.
+	Page int `url:"page,omitempty"`
+
+	// For paginated result sets, the number of results to include per page.
+	PerPage int `url:"perPage,omitempty"`
+}
+
+// queryTimeFormat is the date-time format the API expects in query
+// parameters.
+const queryTimeFormat = "2006-01-02T15:04:05"
+
+// addOptions adds the parameters in opts as URL query parameters to s. opts
+// must be a struct, or a pointer to one, whose fields may carry "url" tags of
+// the form `url:"name,omitempty,comma"`: omitempty skips zero values and
+// comma joins slices into one comma separated parameter. Embedded structs are
+// flattened.
+func addOptions(s string, opts interface{}) (string, error) {
+	v := reflect.ValueOf(opts)
+	if v.Kind() == reflect.Ptr && v.IsNil() {
+		return s, nil
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return s, err
+	}
+	qs := u.Query()
+	if err := encodeOptions(qs, reflect.Indirect(v)); err != nil {
+		return s, err
+	}
+	u.RawQuery = qs.Encode()
+	return u.String(), nil
+}
+
+func encodeOptions(qs url.Values, v reflect.Value) error {
+	if v.Kind() != reflect.Struct {
+		return fmt.Errorf("trustpilot: options must be a struct, not %v", v.Kind())
+	}
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f, fv := t.Field(i), v.Field(i)
+		if f.Anonymous && fv.Kind() == reflect.Struct {
+			if err := encodeOptions(qs, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		tag := f.Tag.Get("url")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name, omitempty, comma := parts[0], false, false
+		for _, p := range parts[1:] {
+			omitempty = omitempty || p == "omitempty"
+			comma = comma || p == "comma"
+		}
+		if omitempty && isZeroValue(fv) {
+			continue
+		}
+		if fv.Kind() == reflect.Ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+		if fv.Kind() == reflect.Slice {
+			vals := make([]string, fv.Len())
+			for j := range vals {
+				vals[j] = formatOption(fv.Index(j))
+			}
+			if comma {
+				qs.Set(name, strings.Join(vals, ","))
+			} else {
+				qs[name] = vals
+			}
+			continue
+		}
+		qs.Set(name, formatOption(fv))
+	}
+	return nil
+}
+
+func formatOption(v reflect.Value) string {
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.UTC().Format(queryTimeFormat)
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+func isZeroValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		return v.IsNil()
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		return t.IsZero()
+	}
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
+
 // Do sends an API request and returns the API response. The API response is
 // JSON decoded and stored in the value pointed to by v, or returned as an
 // error if an API error has occurred. If v implements the io.Writer
@@ -134,11 +345,109 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 //
 // The provided ctx must be non-nil, if it is nil an error is returned. If it is canceled or times out,
 // ctx.Err() will be returned.
-func (c *Client) Do(ctx context.Context, req *http.Request) ([]byte, error) {
+func (c *Client) Do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
 	if ctx == nil {
 		return nil, errors.New("context must be non-nil")
 	}
+	// Requests that carry their own credentials, like the OAuth ones, are
+	// left alone.
+	authorize := c.TokenSource != nil && req.Header.Get("Authorization") == ""
+	// Clone, so that the Authorization header set below stays off the
+	// caller's request and a resent request is authorized afresh.
+	req = req.Clone(ctx)
+	for attempt := 1; ; attempt++ {
+		resp, err := c.do(ctx, req, v, authorize)
+		if !c.RetryPolicy.shouldRetry(req, attempt, resp, err) || !rewindBody(req) {
+			return resp, err
+		}
+		t := time.NewTimer(c.RetryPolicy.delay(attempt, resp, err))
+		select {
+		case <-ctx.Done():
+			t.Stop()
+			return resp, ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+// do makes a single attempt at req, authorizing it through the TokenSource
+// if authorize is set. The response body is only consumed into v if the
+// attempt succeeded, so a failed attempt can be retried.
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}, authorize bool) (*Response, error) {
+	var auth *Authorization
+	if authorize {
+		var err error
+		if auth, err = c.TokenSource.Token(ctx); err != nil {
+			return nil, err
+		}
+		setAuthHeader(req, auth)
+	}
+	resp, err := c.send(ctx, req)
+	if err != nil {
+		if rerr, ok := err.(*RateLimitError); ok {
+			return newResponse(rerr.Response), err
+		}
+		return nil, err
+	}
+	if auth != nil && auth.RefreshToken != nil && resp.StatusCode == http.StatusUnauthorized && rewindBody(req) {
+		// The token was rejected before its expiry; refresh it once and retry.
+		// Should the refresh fail, the 401 is what the caller needs to see.
+		apiErr := CheckResponse(resp)
+		resp.Body.Close()
+		if auth, err = c.TokenSource.refresh(ctx, StringValue(auth.AccessToken)); err != nil {
+			return newResponse(resp), fmt.Errorf("%w (refreshing the access token failed: %v)", apiErr, err)
+		}
+		setAuthHeader(req, auth)
+		if resp, err = c.send(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+	defer resp.Body.Close()
+	response := newResponse(resp)
+
+	err = CheckResponse(resp)
+	if err != nil {
+		return response, err
+	}
+
+	switch v := v.(type) {
+	case nil:
+	case io.Writer:
+		_, err = io.Copy(v, resp.Body)
+	default:
+		decErr := json.NewDecoder(resp.Body).Decode(v)
+		if decErr == io.EOF {
+			decErr = nil // ignore EOF errors caused by empty response body
+		}
+		if decErr != nil {
+			err = decErr
+		}
+	}
+	return response, err
+}
+
+// send makes a single round trip with req, unless the rate limit of its
+// category is known to be exhausted.
+func (c *Client) send(ctx context.Context, req *http.Request) (*http.Response, error) {
+	cat := c.category(req)
+	if err := c.checkRateLimitBeforeDo(req, cat); err != nil {
+		if c.Logger != nil {
+			c.Logger.Debug("trustpilot: rate limit exceeded, not sending request",
+				"method", req.Method,
+				"url", sanitizeURL(req.URL),
+				"rate_reset", err.Rate.Reset)
+		}
+		return nil, err
+	}
+	if l := c.limiter(cat); l != nil {
+		if err := l.Wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+	c.logRequest(req)
+	start := time.Now()
 	resp, err := c.client.Do(req)
+	c.logResponse(req, resp, start, err)
 	if err != nil {
 		// If we got an error, and the context has been canceled,
 		// the context's error is probably more useful.
@@ -149,11 +458,23 @@ func (c *Client) Do(ctx context.Context, req *http.Request) ([]byte, error) {
 		}
 		return nil, err
 	}
-	defer resp.Body.Close()
+	c.updateRate(cat, resp)
+	return resp, nil
+}
 
-	err = CheckResponse(resp)
+// rewindBody resets the body of req so that it can be sent again. It
+// reports false if the body cannot be replayed.
+func rewindBody(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return true
+	}
+	if req.GetBody == nil {
+		return false
+	}
+	body, err := req.GetBody()
 	if err != nil {
-		return nil, err
+		return false
 	}
-	return ioutil.ReadAll(resp.Body)
+	req.Body = body
+	return true
 }
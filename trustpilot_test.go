@@ -0,0 +1,210 @@
+package trustpilot
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewClient(t *testing.T) {
+	c := NewClient(nil)
+
+	if got, want := c.BaseURL.String(), defaultBaseURL; got != want {
+		t.Errorf("NewClient BaseURL is %v, want %v", got, want)
+	}
+	if got, want := c.AuthURL.String(), defaultAuthURL; got != want {
+		t.Errorf("NewClient AuthURL is %v, want %v", got, want)
+	}
+	if got, want := c.AccessTokenURL.String(), defaultAccessTokenURL; got != want {
+		t.Errorf("NewClient AccessTokenURL is %v, want %v", got, want)
+	}
+	if got, want := c.RefreshURL.String(), defaultRefreshURL; got != want {
+		t.Errorf("NewClient RefreshURL is %v, want %v", got, want)
+	}
+	if got, want := c.RevokeURL.String(), defaultRevokeURL; got != want {
+		t.Errorf("NewClient RevokeURL is %v, want %v", got, want)
+	}
+}
+
+func TestClient_WithEndpoints(t *testing.T) {
+	prod := NewClient(nil)
+	local, err := NewClient(nil).WithEndpoints(Endpoints{
+		BaseURL:        "http://localhost:8005/trustpilot",
+		AccessTokenURL: "http://localhost:8005/trustpilot/accesstoken",
+		InvitationsURL: "http://localhost:8005/invitations",
+	})
+	if err != nil {
+		t.Fatalf("WithEndpoints returned error: %v", err)
+	}
+
+	if got, want := local.BaseURL.String(), "http://localhost:8005/trustpilot/"; got != want {
+		t.Errorf("WithEndpoints BaseURL is %v, want %v", got, want)
+	}
+	if got, want := local.AccessTokenURL.String(), "http://localhost:8005/trustpilot/accesstoken"; got != want {
+		t.Errorf("WithEndpoints AccessTokenURL is %v, want %v", got, want)
+	}
+	if got, want := local.InvitationsURL.String(), "http://localhost:8005/invitations/"; got != want {
+		t.Errorf("WithEndpoints InvitationsURL is %v, want %v", got, want)
+	}
+	if got, want := local.RevokeURL.String(), defaultRevokeURL; got != want {
+		t.Errorf("WithEndpoints RevokeURL is %v, want %v", got, want)
+	}
+	if got, want := prod.BaseURL.String(), defaultBaseURL; got != want {
+		t.Errorf("other client BaseURL is %v, want %v", got, want)
+	}
+}
+
+func TestClient_WithEndpoints_badURL(t *testing.T) {
+	_, err := NewClient(nil).WithEndpoints(Endpoints{RevokeURL: ":"})
+	testURLParseError(t, err)
+
+	c := NewClient(nil)
+	_, err = c.WithEndpoints(Endpoints{BaseURL: "http://stand-in/", AuthURL: "://bad"})
+	testURLParseError(t, err)
+	if got, want := c.BaseURL.String(), defaultBaseURL; got != want {
+		t.Errorf("BaseURL is %v after a failed WithEndpoints, want %v", got, want)
+	}
+}
+
+func TestNewFormRequest(t *testing.T) {
+	c := NewClient(nil)
+
+	form := url.Values{}
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", "dead meat")
+	req, err := c.NewFormRequest("POST", "oauth/refresh", form)
+	if err != nil {
+		t.Fatalf("NewFormRequest returned error: %v", err)
+	}
+
+	if got, want := req.URL.String(), defaultBaseURL+"oauth/refresh"; got != want {
+		t.Errorf("NewFormRequest URL is %v, want %v", got, want)
+	}
+	if got, want := req.Header.Get("Content-Type"), "application/x-www-form-urlencoded"; got != want {
+		t.Errorf("NewFormRequest Content-Type is %v, want %v", got, want)
+	}
+	body, _ := ioutil.ReadAll(req.Body)
+	if got, want := string(body), "grant_type=refresh_token&refresh_token=dead+meat"; got != want {
+		t.Errorf("NewFormRequest body is %v, want %v", got, want)
+	}
+}
+
+func TestDo_contextDeadline(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	done := make(chan struct{})
+	defer close(done)
+	mux.HandleFunc("/reviews/latest", func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-done:
+		}
+	})
+	cctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, _, err := client.Business.GetServiceReviews(cctx, nil)
+	if err != context.DeadlineExceeded {
+		t.Errorf("GetServiceReviews returned error %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDo_nilContext(t *testing.T) {
+	client := NewClient(nil)
+	req, _ := client.NewRequest("GET", "reviews/latest", nil)
+	if _, err := client.Do(nil, req, nil); err == nil {
+		t.Errorf("Do returned no error for a nil context")
+	}
+}
+
+func TestDo_decodesIntoValue(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/things", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+	type foo struct{ A string }
+
+	req, _ := client.NewRequest("GET", "things", nil)
+	body := new(foo)
+	if _, err := client.Do(context.Background(), req, body); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if want := (&foo{A: "a"}); !reflect.DeepEqual(body, want) {
+		t.Errorf("Do decoded %v, want %v", body, want)
+	}
+}
+
+func TestDo_writesToWriter(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/things", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `raw body`)
+	})
+
+	req, _ := client.NewRequest("GET", "things", nil)
+	var buf bytes.Buffer
+	if _, err := client.Do(context.Background(), req, &buf); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if got, want := buf.String(), "raw body"; got != want {
+		t.Errorf("Do wrote %q, want %q", got, want)
+	}
+}
+
+func TestDo_emptyBody(t *testing.T) {
+	client, mux, _, teardown := bsetup()
+	defer teardown()
+	mux.HandleFunc("/things", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req, _ := client.NewRequest("DELETE", "things", nil)
+	if _, err := client.Do(context.Background(), req, &struct{}{}); err != nil {
+		t.Errorf("Do returned error for an empty body: %v", err)
+	}
+}
+
+func TestAddOptions(t *testing.T) {
+	type inner struct {
+		N int `url:"n,omitempty"`
+	}
+	type opts struct {
+		S     string    `url:"s,omitempty"`
+		Empty string    `url:"empty,omitempty"`
+		Zero  int       `url:"zero"`
+		B     *bool     `url:"b,omitempty"`
+		L     []string  `url:"l,omitempty"`
+		C     []int     `url:"c,omitempty,comma"`
+		T     time.Time `url:"t,omitempty"`
+		Skip  string
+		inner
+	}
+
+	got, err := addOptions("reviews?x=1", &opts{
+		S:     "a b",
+		B:     Bool(false),
+		L:     []string{"p", "q"},
+		C:     []int{1, 2},
+		T:     time.Date(2013, 9, 7, 13, 37, 0, 0, time.UTC),
+		Skip:  "skipped",
+		inner: inner{N: 3},
+	})
+	if err != nil {
+		t.Fatalf("addOptions returned error: %v", err)
+	}
+	want := "reviews?b=false&c=1%2C2&l=p&l=q&n=3&s=a+b&t=2013-09-07T13%3A37%3A00&x=1&zero=0"
+	if got != want {
+		t.Errorf("addOptions returned %v, want %v", got, want)
+	}
+
+	var nilOpts *opts
+	if got, _ := addOptions("reviews", nilOpts); got != "reviews" {
+		t.Errorf("addOptions with nil options returned %v, want reviews", got)
+	}
+}